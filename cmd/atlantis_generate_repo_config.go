@@ -54,5 +54,10 @@ func init() {
 	atlantisGenerateRepoConfigCmd.PersistentFlags().String("ssh-key", "", "Path to PEM-encoded private key to clone private repos using SSH: atmos atlantis generate repo-config --affected-only=true --ssh-key <path_to_ssh_key>")
 	atlantisGenerateRepoConfigCmd.PersistentFlags().String("ssh-key-password", "", "Encryption password for the PEM-encoded private key if the key contains a password-encrypted PEM block: atmos atlantis generate repo-config --affected-only=true --ssh-key <path_to_ssh_key> --ssh-key-password <password>")
 
+	atlantisGenerateRepoConfigCmd.PersistentFlags().Bool("with-files", false,
+		"Also write the varfile and the backend config ('<project>.backend.tfbackend') for each Atlantis project, so a single pre-workflow hook can prepare the whole repo.\n"+
+			"atmos atlantis generate repo-config --config-template <config_template> --project-template <project_template> --with-files=true",
+	)
+
 	atlantisGenerateCmd.AddCommand(atlantisGenerateRepoConfigCmd)
 }
@@ -0,0 +1,17 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// ciCmd executes CI/CD commands
+var ciCmd = &cobra.Command{
+	Use:                "ci",
+	Short:              "Execute 'ci' commands",
+	Long:               `This command executes CI/CD (GitLab CI, Buildkite) integration commands`,
+	FParseErrWhitelist: struct{ UnknownFlags bool }{UnknownFlags: false},
+}
+
+func init() {
+	RootCmd.AddCommand(ciCmd)
+}
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	e "github.com/cloudposse/atmos/internal/exec"
+	u "github.com/cloudposse/atmos/pkg/utils"
+)
+
+// ciGenerateCmd generates GitLab CI or Buildkite pipelines
+var ciGenerateCmd = &cobra.Command{
+	Use:                "generate",
+	Short:              "Execute 'ci generate' command",
+	Long:               "This command generates a GitLab CI child pipeline or a Buildkite pipeline with plan and apply jobs for the terraform components in the stacks",
+	FParseErrWhitelist: struct{ UnknownFlags bool }{UnknownFlags: false},
+	Run: func(cmd *cobra.Command, args []string) {
+		err := e.ExecuteCIGenerateCmd(cmd, args)
+		if err != nil {
+			u.LogErrorAndExit(err)
+		}
+	},
+}
+
+func init() {
+	ciGenerateCmd.DisableFlagParsing = false
+
+	ciGenerateCmd.PersistentFlags().String("provider", "", "CI provider: 'gitlab' or 'buildkite'. atmos ci generate --provider gitlab --job-template job-1")
+	ciGenerateCmd.PersistentFlags().String("output-path", "", "atmos ci generate --provider gitlab --output-path .gitlab/atmos-pipeline.yml --job-template job-1")
+	ciGenerateCmd.PersistentFlags().String("pipeline-template", "", "atmos ci generate --provider gitlab --pipeline-template pipeline-1 --job-template job-1")
+	ciGenerateCmd.PersistentFlags().String("job-template", "", "atmos ci generate --provider gitlab --pipeline-template pipeline-1 --job-template job-1")
+
+	ciGenerateCmd.PersistentFlags().String("stacks", "",
+		"Generate jobs for the specified stacks only (comma-separated values).\n"+
+			"atmos ci generate --provider gitlab --job-template <job_template> --stacks <stack1>,<stack2>\n"+
+			"The filter can contain the names of the top-level stack manifests and the logical stack names (derived from the context vars)",
+	)
+
+	ciGenerateCmd.PersistentFlags().String("components", "",
+		"Generate jobs for the specified components only (comma-separated values).\n"+
+			"atmos ci generate --provider gitlab --job-template <job_template> --components <component1>,<component2>",
+	)
+
+	ciGenerateCmd.PersistentFlags().Bool("affected-only", false,
+		"Generate jobs only for the Atmos components changed between two Git commits.\n"+
+			"atmos ci generate --provider gitlab --affected-only=true",
+	)
+
+	ciGenerateCmd.PersistentFlags().String("repo-path", "", "Filesystem path to the already cloned target repository with which to compare the current branch: atmos ci generate --provider gitlab --affected-only=true --repo-path <path_to_already_cloned_repo>")
+	ciGenerateCmd.PersistentFlags().String("ref", "", "Git reference with which to compare the current branch: atmos ci generate --provider gitlab --affected-only=true --ref refs/heads/main. Refer to https://git-scm.com/book/en/v2/Git-Internals-Git-References for more details")
+	ciGenerateCmd.PersistentFlags().String("sha", "", "Git commit SHA with which to compare the current branch: atmos ci generate --provider gitlab --affected-only=true --sha 3a5eafeab90426bd82bf5899896b28cc0bab3073")
+	ciGenerateCmd.PersistentFlags().Bool("verbose", false, "Print more detailed output when cloning and checking out the Git repository: atmos ci generate --provider gitlab --affected-only=true --verbose=true")
+	ciGenerateCmd.PersistentFlags().String("ssh-key", "", "Path to PEM-encoded private key to clone private repos using SSH: atmos ci generate --provider gitlab --affected-only=true --ssh-key <path_to_ssh_key>")
+	ciGenerateCmd.PersistentFlags().String("ssh-key-password", "", "Encryption password for the PEM-encoded private key if the key contains a password-encrypted PEM block: atmos ci generate --provider gitlab --affected-only=true --ssh-key <path_to_ssh_key> --ssh-key-password <password>")
+
+	err := ciGenerateCmd.MarkPersistentFlagRequired("provider")
+	if err != nil {
+		u.LogErrorAndExit(err)
+	}
+
+	ciCmd.AddCommand(ciGenerateCmd)
+}
@@ -271,3 +271,27 @@ func cloneCommand(orig *schema.Command) (*schema.Command, error) {
 
 	return &clone, nil
 }
+
+// initCliConfigFromFlags finds and merges the CLI configurations, including the CLI config file from the `--config` command-line flag
+func initCliConfigFromFlags(cmd *cobra.Command) (schema.CliConfiguration, error) {
+	cliConfigFile, err := cmd.Flags().GetString("config")
+	if err != nil {
+		return schema.CliConfiguration{}, err
+	}
+
+	return cfg.InitCliConfig(schema.ConfigAndStacksInfo{CliConfigFile: cliConfigFile}, false)
+}
+
+// cliConfigFileFromArgs returns the CLI config file from the `--config` flag in the command-line arguments.
+// It's used before the command-line flags are parsed (e.g. to find the custom commands in the CLI config file)
+func cliConfigFileFromArgs(args []string) string {
+	for i, arg := range args {
+		if arg == cfg.CliConfigFileFlag && len(args) > i+1 {
+			return args[i+1]
+		}
+		if strings.HasPrefix(arg, cfg.CliConfigFileFlag+"=") {
+			return strings.TrimPrefix(arg, cfg.CliConfigFileFlag+"=")
+		}
+	}
+	return ""
+}
@@ -28,11 +28,16 @@ func init() {
 	describeAffectedCmd.PersistentFlags().String("ref", "", "Git reference with which to compare the current branch: atmos describe affected --ref refs/heads/main. Refer to https://git-scm.com/book/en/v2/Git-Internals-Git-References for more details")
 	describeAffectedCmd.PersistentFlags().String("sha", "", "Git commit SHA with which to compare the current branch: atmos describe affected --sha 3a5eafeab90426bd82bf5899896b28cc0bab3073")
 	describeAffectedCmd.PersistentFlags().String("file", "", "Write the result to the file: atmos describe affected --ref refs/tags/v1.16.0 --file affected.json")
-	describeAffectedCmd.PersistentFlags().String("format", "json", "The output format: atmos describe affected --format=json|yaml ('json' is default)")
+	describeAffectedCmd.PersistentFlags().String("format", "json", "The output format: atmos describe affected --format=json|yaml|matrix|markdown ('json' is default). 'matrix' outputs a GitHub Actions matrix of the affected components, 'markdown' outputs a summary for pull request comments")
 	describeAffectedCmd.PersistentFlags().Bool("verbose", false, "Print more detailed output when cloning and checking out the Git repository: atmos describe affected --verbose=true")
 	describeAffectedCmd.PersistentFlags().String("ssh-key", "", "Path to PEM-encoded private key to clone private repos using SSH: atmos describe affected --ssh-key <path_to_ssh_key>")
 	describeAffectedCmd.PersistentFlags().String("ssh-key-password", "", "Encryption password for the PEM-encoded private key if the key contains a password-encrypted PEM block: atmos describe affected --ssh-key <path_to_ssh_key> --ssh-key-password <password>")
 	describeAffectedCmd.PersistentFlags().Bool("include-spacelift-admin-stacks", false, "Include the Spacelift admin stack of any stack that is affected by config changes: atmos describe affected --include-spacelift-admin-stacks=true")
+	describeAffectedCmd.PersistentFlags().String("matrix-group-by", "", "Group the GitHub Actions matrix by the dependency level of the affected components, so each level can be a separate job: atmos describe affected --format=matrix --matrix-group-by=dependency-level")
+	describeAffectedCmd.PersistentFlags().Bool("github-output", false, "Write the GitHub Actions matrix to the file pointed to by the 'GITHUB_OUTPUT' ENV var: atmos describe affected --format=matrix --github-output=true")
+
+	describeAffectedCmd.PersistentFlags().Bool("plan-summary", false, "Add the number of resources to add, change and destroy from the planfiles saved by 'atmos terraform plan' to the markdown output: atmos describe affected --format=markdown --plan-summary=true")
+	describeAffectedCmd.PersistentFlags().String("markdown-template", "", "Path to a Go template to render the markdown output: atmos describe affected --format=markdown --markdown-template=affected.md.tmpl")
 
 	describeCmd.AddCommand(describeAffectedCmd)
 }
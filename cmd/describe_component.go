@@ -26,6 +26,7 @@ func init() {
 	describeComponentCmd.PersistentFlags().StringP("stack", "s", "", "atmos describe component <component> -s <stack>")
 	describeComponentCmd.PersistentFlags().StringP("format", "f", "yaml", "The output format: atmos describe component <component> -s <stack> --format=yaml|json ('yaml' is default)")
 	describeComponentCmd.PersistentFlags().String("file", "", "Write the result to the file: atmos describe component <component> -s <stack> --file component.yaml")
+	describeComponentCmd.PersistentFlags().Bool("provenance", false, "Show the component in the inheritance chain and the stack manifest file and line where each final value is set: atmos describe component <component> -s <stack> --provenance")
 
 	err := describeComponentCmd.MarkPersistentFlagRequired("stack")
 	if err != nil {
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"errors"
+	"os"
 
 	"github.com/elewis787/boa"
 	"github.com/spf13/cobra"
@@ -33,12 +34,17 @@ func Execute() error {
 }
 
 func init() {
+	// The `--config` flag is registered before the command-line flags are parsed, since the commands read it
+	RootCmd.PersistentFlags().String("config", "", "Path to the CLI config file that is merged on top of the other CLI config files "+
+		"('atmos.yaml' in the system dir, home dir, current dir and 'ATMOS_CLI_CONFIG_PATH'): atmos <command> --config path/to/atmos.yaml")
+
 	cobra.OnInitialize(initConfig)
 
 	// InitCliConfig finds and merges CLI configurations in the following order:
-	// system dir, home dir, current dir, ENV vars, command-line arguments
-	// Here we need the custom commands from the config
-	cliConfig, err := cfg.InitCliConfig(schema.ConfigAndStacksInfo{}, false)
+	// system dir, home dir, current dir, the file from the `--config` flag, ENV vars, command-line arguments
+	// Here we need the custom commands from the config.
+	// The command-line flags are not parsed yet, so the `--config` flag is found in the command-line arguments
+	cliConfig, err := cfg.InitCliConfig(schema.ConfigAndStacksInfo{CliConfigFile: cliConfigFileFromArgs(os.Args[1:])}, false)
 	if err != nil && !errors.Is(err, cfg.NotFound) {
 		u.LogErrorAndExit(err)
 	}
@@ -0,0 +1,17 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// spaceliftGenerateCmd generates various Spacelift configurations
+var spaceliftGenerateCmd = &cobra.Command{
+	Use:                "generate",
+	Short:              "Execute 'spacelift generate' commands",
+	Long:               "This command generates various Spacelift configurations",
+	FParseErrWhitelist: struct{ UnknownFlags bool }{UnknownFlags: false},
+}
+
+func init() {
+	spaceliftCmd.AddCommand(spaceliftGenerateCmd)
+}
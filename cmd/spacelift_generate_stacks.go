@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	e "github.com/cloudposse/atmos/internal/exec"
+	u "github.com/cloudposse/atmos/pkg/utils"
+)
+
+// spaceliftGenerateStacksCmd generates the Spacelift stack configs
+var spaceliftGenerateStacksCmd = &cobra.Command{
+	Use:                "stacks",
+	Short:              "Execute 'spacelift generate stacks'",
+	Long:               "This command generates the Spacelift stack configs from the Atmos stacks, or the Terraform/OpenTofu resources to manage the Spacelift stacks",
+	FParseErrWhitelist: struct{ UnknownFlags bool }{UnknownFlags: false},
+	Run: func(cmd *cobra.Command, args []string) {
+		err := e.ExecuteSpaceliftGenerateStacksCmd(cmd, args)
+		if err != nil {
+			u.LogErrorAndExit(err)
+		}
+	},
+}
+
+func init() {
+	spaceliftGenerateStacksCmd.DisableFlagParsing = false
+
+	spaceliftGenerateStacksCmd.PersistentFlags().String("file", "", "Write the result to file: atmos spacelift generate stacks --file=spacelift-stacks.json")
+
+	spaceliftGenerateStacksCmd.PersistentFlags().String("format", "json",
+		"Specify the output format: atmos spacelift generate stacks --format=json|yaml|tf-json ('json' is default).\n"+
+			"'json' and 'yaml' output the Spacelift stack configs consumed by the Spacelift admin stack.\n"+
+			"'tf-json' outputs the Terraform/OpenTofu JSON config with the 'spacelift_stack', 'spacelift_stack_dependency' and 'spacelift_stack_destructor' resources",
+	)
+
+	spaceliftGenerateStacksCmd.PersistentFlags().String("stack-config-path-template", "stacks/%s.yaml",
+		"Template to build the paths to the stack manifests in the 'import', 'stack' and 'deps' labels: atmos spacelift generate stacks --stack-config-path-template=stacks/%s.yaml",
+	)
+
+	spaceliftGenerateStacksCmd.PersistentFlags().String("repository", "",
+		"Repository for the Spacelift stacks that don't specify 'settings.spacelift.repository' (used with '--format=tf-json'): atmos spacelift generate stacks --format=tf-json --repository=infrastructure",
+	)
+
+	spaceliftGenerateStacksCmd.PersistentFlags().String("branch", "main",
+		"Branch for the Spacelift stacks that don't specify 'settings.spacelift.branch' (used with '--format=tf-json'): atmos spacelift generate stacks --format=tf-json --branch=main",
+	)
+
+	spaceliftGenerateCmd.AddCommand(spaceliftGenerateStacksCmd)
+}
@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	e "github.com/cloudposse/atmos/internal/exec"
+	u "github.com/cloudposse/atmos/pkg/utils"
+)
+
+// terraformGenerateSchemaCmd generates a JSON Schema for the `vars` section of a terraform component
+var terraformGenerateSchemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Execute 'terraform generate schema' command",
+	Long: `This command generates a JSON Schema for the 'vars' section of a terraform component from the component's variables: ` +
+		`atmos terraform generate schema <component> -f <file>`,
+	FParseErrWhitelist: struct{ UnknownFlags bool }{UnknownFlags: false},
+	Run: func(cmd *cobra.Command, args []string) {
+		err := e.ExecuteTerraformGenerateSchemaCmd(cmd, args)
+		if err != nil {
+			u.LogErrorAndExit(err)
+		}
+	},
+}
+
+func init() {
+	terraformGenerateSchemaCmd.DisableFlagParsing = false
+	terraformGenerateSchemaCmd.PersistentFlags().StringP("stack", "s", "", "Generate the schema for the terraform component that the Atmos component in the stack points to: "+
+		"atmos terraform generate schema <component> -s <stack>")
+	terraformGenerateSchemaCmd.PersistentFlags().StringP("file", "f", "", "atmos terraform generate schema <component> -f <file>")
+
+	terraformGenerateCmd.AddCommand(terraformGenerateSchemaCmd)
+}
@@ -0,0 +1,17 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// tfcCmd executes Terraform Cloud/Enterprise commands
+var tfcCmd = &cobra.Command{
+	Use:                "tfc",
+	Short:              "Execute 'tfc' commands",
+	Long:               `This command executes Terraform Cloud/Enterprise integration commands`,
+	FParseErrWhitelist: struct{ UnknownFlags bool }{UnknownFlags: false},
+}
+
+func init() {
+	RootCmd.AddCommand(tfcCmd)
+}
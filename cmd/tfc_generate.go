@@ -0,0 +1,17 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// tfcGenerateCmd generates various Terraform Cloud/Enterprise configurations
+var tfcGenerateCmd = &cobra.Command{
+	Use:                "generate",
+	Short:              "Execute 'tfc generate' commands",
+	Long:               "This command generates various Terraform Cloud/Enterprise configurations",
+	FParseErrWhitelist: struct{ UnknownFlags bool }{UnknownFlags: false},
+}
+
+func init() {
+	tfcCmd.AddCommand(tfcGenerateCmd)
+}
@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	e "github.com/cloudposse/atmos/internal/exec"
+	u "github.com/cloudposse/atmos/pkg/utils"
+)
+
+// tfcGenerateWorkspacesCmd generates the Terraform Cloud/Enterprise workspaces
+var tfcGenerateWorkspacesCmd = &cobra.Command{
+	Use:                "workspaces",
+	Short:              "Execute 'tfc generate workspaces'",
+	Long:               "This command generates the Terraform/OpenTofu JSON config with the 'tfe' provider resources to manage the Terraform Cloud/Enterprise workspaces",
+	FParseErrWhitelist: struct{ UnknownFlags bool }{UnknownFlags: false},
+	Run: func(cmd *cobra.Command, args []string) {
+		err := e.ExecuteTfcGenerateWorkspacesCmd(cmd, args)
+		if err != nil {
+			u.LogErrorAndExit(err)
+		}
+	},
+}
+
+func init() {
+	tfcGenerateWorkspacesCmd.DisableFlagParsing = false
+
+	tfcGenerateWorkspacesCmd.PersistentFlags().String("file", "", "Write the result to file: atmos tfc generate workspaces --file=tfc/workspaces.tf.json")
+
+	tfcGenerateWorkspacesCmd.PersistentFlags().String("organization", "",
+		"Organization for the workspaces that don't specify 'settings.tfc.organization': atmos tfc generate workspaces --organization=acme",
+	)
+
+	tfcGenerateCmd.AddCommand(tfcGenerateWorkspacesCmd)
+}
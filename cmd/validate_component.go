@@ -4,8 +4,6 @@ import (
 	"github.com/spf13/cobra"
 
 	e "github.com/cloudposse/atmos/internal/exec"
-	cfg "github.com/cloudposse/atmos/pkg/config"
-	"github.com/cloudposse/atmos/pkg/schema"
 	u "github.com/cloudposse/atmos/pkg/utils"
 )
 
@@ -13,9 +11,9 @@ import (
 var validateComponentCmd = &cobra.Command{
 	Use:   "component",
 	Short: "Execute 'validate component' command",
-	Long:  `This command validates an atmos component in a stack using Json Schema or OPA policies: atmos validate component <component> -s <stack> --schema-path <schema_path> --schema-type <jsonschema|opa>`,
+	Long:  `This command validates an atmos component in a stack using JSON Schema, CUE schemas or OPA policies: atmos validate component <component> -s <stack> --schema-path <schema_path> --schema-type <jsonschema|opa|cue>`,
 	Example: "atmos validate component <component> -s <stack>\n" +
-		"atmos validate component <component> -s <stack> --schema-path <schema_path> --schema-type <jsonschema|opa>\n" +
+		"atmos validate component <component> -s <stack> --schema-path <schema_path> --schema-type <jsonschema|opa|cue>\n" +
 		"atmos validate component <component> -s <stack> --schema-path <schema_path> --schema-type opa --module-paths catalog",
 	FParseErrWhitelist: struct{ UnknownFlags bool }{UnknownFlags: false},
 	Run: func(cmd *cobra.Command, args []string) {
@@ -24,7 +22,7 @@ var validateComponentCmd = &cobra.Command{
 			u.LogErrorAndExit(err)
 		}
 
-		cliConfig, err := cfg.InitCliConfig(schema.ConfigAndStacksInfo{}, false)
+		cliConfig, err := initCliConfigFromFlags(cmd)
 		if err != nil {
 			u.LogErrorAndExit(err)
 		}
@@ -36,11 +34,12 @@ var validateComponentCmd = &cobra.Command{
 func init() {
 	validateComponentCmd.DisableFlagParsing = false
 
-	validateComponentCmd.PersistentFlags().StringP("stack", "s", "", "atmos validate component <component> -s <stack> --schema-path <schema_path> --schema-type <jsonschema|opa>")
-	validateComponentCmd.PersistentFlags().String("schema-path", "", "atmos validate component <component> -s <stack> --schema-path <schema_path> --schema-type <jsonschema|opa>")
-	validateComponentCmd.PersistentFlags().String("schema-type", "", "atmos validate component <component> -s <stack> --schema-path <schema_path> --schema-type <jsonschema|opa>")
+	validateComponentCmd.PersistentFlags().StringP("stack", "s", "", "atmos validate component <component> -s <stack> --schema-path <schema_path> --schema-type <jsonschema|opa|cue>")
+	validateComponentCmd.PersistentFlags().String("schema-path", "", "atmos validate component <component> -s <stack> --schema-path <schema_path> --schema-type <jsonschema|opa|cue>")
+	validateComponentCmd.PersistentFlags().String("schema-type", "", "atmos validate component <component> -s <stack> --schema-path <schema_path> --schema-type <jsonschema|opa|cue>")
 	validateComponentCmd.PersistentFlags().StringSlice("module-paths", nil, "atmos validate component <component> -s <stack> --schema-path <schema_path> --schema-type opa --module-paths catalog")
 	validateComponentCmd.PersistentFlags().Int("timeout", 0, "Validation timeout in seconds: atmos validate component <component> -s <stack> --timeout 15")
+	validateComponentCmd.PersistentFlags().Bool("no-validation-cache", false, "Don't use the cached validation results: atmos validate component <component> -s <stack> --no-validation-cache")
 
 	err := validateComponentCmd.MarkPersistentFlagRequired("stack")
 	if err != nil {
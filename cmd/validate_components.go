@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	e "github.com/cloudposse/atmos/internal/exec"
+	u "github.com/cloudposse/atmos/pkg/utils"
+)
+
+// validateComponentsCmd validates all atmos components in all stacks
+var validateComponentsCmd = &cobra.Command{
+	Use:   "components",
+	Short: "Execute 'validate components' command",
+	Long:  `This command validates all atmos components in all stacks using the policies from the components' 'settings.validation' sections: atmos validate components`,
+	Example: "atmos validate components\n" +
+		"atmos validate components --stacks <stack1>,<stack2> --components <component1>,<component2>\n" +
+		"atmos validate components --format junit --file validation.xml\n" +
+		"atmos validate components --format sarif --file validation.sarif",
+	FParseErrWhitelist: struct{ UnknownFlags bool }{UnknownFlags: false},
+	Run: func(cmd *cobra.Command, args []string) {
+		err := e.ExecuteValidateComponentsCmd(cmd, args)
+		if err != nil {
+			u.LogErrorAndExit(err)
+		}
+
+		// The JUnit and SARIF reports printed to stdout are machine-readable, don't append the message to them
+		format, _ := cmd.Flags().GetString("format")
+		file, _ := cmd.Flags().GetString("file")
+		if file == "" && (format == "junit" || format == "sarif") {
+			return
+		}
+
+		cliConfig, err := initCliConfigFromFlags(cmd)
+		if err != nil {
+			u.LogErrorAndExit(err)
+		}
+
+		u.LogInfo(cliConfig, "all components validated successfully\n")
+	},
+}
+
+func init() {
+	validateComponentsCmd.DisableFlagParsing = false
+
+	validateComponentsCmd.PersistentFlags().String("stacks", "",
+		"Validate the components in the specified stacks only (comma-separated values).\n"+
+			"The filter can contain the names of the top-level stack manifests and the logical stack names (derived from the context vars)\n"+
+			"atmos validate components --stacks orgs/cp/tenant1/staging/us-east-2,tenant1-ue2-prod",
+	)
+	validateComponentsCmd.PersistentFlags().String("components", "",
+		"Validate the specified components only (comma-separated values): atmos validate components --components <component1>,<component2>",
+	)
+	validateComponentsCmd.PersistentFlags().String("format", "text", "Specify the report format: atmos validate components --format=text|junit|sarif ('text' is default)")
+	validateComponentsCmd.PersistentFlags().String("file", "", "Write the report to file: atmos validate components --format=junit --file=validation.xml")
+	validateComponentsCmd.PersistentFlags().Int("parallelism", 0, "Number of validations to run in parallel (defaults to the number of CPUs): atmos validate components --parallelism 4")
+	validateComponentsCmd.PersistentFlags().Bool("no-validation-cache", false, "Don't use the cached validation results: atmos validate components --no-validation-cache")
+
+	validateCmd.AddCommand(validateComponentsCmd)
+}
@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	e "github.com/cloudposse/atmos/internal/exec"
+	u "github.com/cloudposse/atmos/pkg/utils"
+)
+
+// validateConfigCmd validates the CLI config files
+var validateConfigCmd = &cobra.Command{
+	Use:                "config",
+	Short:              "Execute 'validate config' command",
+	Long:               `This command validates all the CLI config files ('atmos.yaml') that are merged into the CLI config: atmos validate config`,
+	Example:            "atmos validate config",
+	FParseErrWhitelist: struct{ UnknownFlags bool }{UnknownFlags: false},
+	Run: func(cmd *cobra.Command, args []string) {
+		err := e.ExecuteValidateConfigCmd(cmd, args)
+		if err != nil {
+			u.LogErrorAndExit(err)
+		}
+
+		cliConfig, err := initCliConfigFromFlags(cmd)
+		if err != nil {
+			u.LogErrorAndExit(err)
+		}
+
+		u.LogInfo(cliConfig, "all CLI config files validated successfully\n")
+	},
+}
+
+func init() {
+	validateConfigCmd.DisableFlagParsing = false
+
+	validateCmd.AddCommand(validateConfigCmd)
+}
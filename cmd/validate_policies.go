@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	e "github.com/cloudposse/atmos/internal/exec"
+	u "github.com/cloudposse/atmos/pkg/utils"
+)
+
+// validatePoliciesCmd validates stacks using the stack policies
+var validatePoliciesCmd = &cobra.Command{
+	Use:   "policies",
+	Short: "Execute 'validate policies' command",
+	Long:  `This command validates the stacks using the policies from the 'validate.policies' section in 'atmos.yaml': atmos validate policies`,
+	Example: "atmos validate policies\n" +
+		"atmos validate policies --policies <policy1>,<policy2>",
+	FParseErrWhitelist: struct{ UnknownFlags bool }{UnknownFlags: false},
+	Run: func(cmd *cobra.Command, args []string) {
+		err := e.ExecuteValidatePoliciesCmd(cmd, args)
+		if err != nil {
+			u.LogErrorAndExit(err)
+		}
+
+		cliConfig, err := initCliConfigFromFlags(cmd)
+		if err != nil {
+			u.LogErrorAndExit(err)
+		}
+
+		u.LogInfo(cliConfig, "all policies validated successfully\n")
+	},
+}
+
+func init() {
+	validatePoliciesCmd.DisableFlagParsing = false
+
+	validatePoliciesCmd.PersistentFlags().String("policies", "",
+		"Validate the stacks using the specified policies only (comma-separated values): atmos validate policies --policies <policy1>,<policy2>",
+	)
+
+	validateCmd.AddCommand(validatePoliciesCmd)
+}
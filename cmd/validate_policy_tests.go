@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	e "github.com/cloudposse/atmos/internal/exec"
+	cfg "github.com/cloudposse/atmos/pkg/config"
+	"github.com/cloudposse/atmos/pkg/schema"
+	u "github.com/cloudposse/atmos/pkg/utils"
+)
+
+// validateTestCmd runs the tests of the validation policies
+var validateTestCmd = &cobra.Command{
+	Use:   "test",
+	Short: "Execute 'validate test' command",
+	Long:  `This command runs the OPA tests ('*_test.rego' files) and the fixture-based tests ('*_test.yaml' files) of the validation policies: atmos validate test`,
+	Example: "atmos validate test\n" +
+		"atmos validate test --run vpc\n" +
+		"atmos validate test --module-paths catalog/constants",
+	FParseErrWhitelist: struct{ UnknownFlags bool }{UnknownFlags: false},
+	Run: func(cmd *cobra.Command, args []string) {
+		err := e.ExecuteValidateTestCmd(cmd, args)
+		if err != nil {
+			u.LogErrorAndExit(err)
+		}
+
+		cliConfig, err := cfg.InitCliConfig(schema.ConfigAndStacksInfo{}, false)
+		if err != nil {
+			u.LogErrorAndExit(err)
+		}
+
+		u.LogInfo(cliConfig, "all tests passed\n")
+	},
+}
+
+func init() {
+	validateTestCmd.DisableFlagParsing = false
+
+	validateTestCmd.PersistentFlags().String("run", "", "Run only the tests with the names matching the regular expression: atmos validate test --run <regex>")
+	validateTestCmd.PersistentFlags().StringSlice("module-paths", nil,
+		"Additional Rego modules for the OPA tests (paths relative to 'schemas.opa.base_path'): atmos validate test --module-paths catalog/constants",
+	)
+
+	validateCmd.AddCommand(validateTestCmd)
+}
@@ -0,0 +1,17 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// validateSchemaCmd commands work with the JSON Schemas bundled with Atmos
+var validateSchemaCmd = &cobra.Command{
+	Use:                "schema",
+	Short:              "Execute 'validate schema' commands",
+	Long:               "This command works with the JSON Schemas bundled with Atmos",
+	FParseErrWhitelist: struct{ UnknownFlags bool }{UnknownFlags: false},
+}
+
+func init() {
+	validateCmd.AddCommand(validateSchemaCmd)
+}
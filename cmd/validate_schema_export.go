@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	e "github.com/cloudposse/atmos/internal/exec"
+	u "github.com/cloudposse/atmos/pkg/utils"
+)
+
+// validateSchemaExportCmd exports the JSON Schema bundled with Atmos
+var validateSchemaExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Execute 'validate schema export' command",
+	Long: `This command exports the JSON Schema bundled with Atmos (e.g. to configure autocompletion and validation in editors): ` +
+		`atmos validate schema export --type manifest -f <file>`,
+	Example: "atmos validate schema export\n" +
+		"atmos validate schema export -f schemas/atmos-manifest.json\n" +
+		"atmos validate schema export --type config -f schemas/atmos-cli-config.json",
+	FParseErrWhitelist: struct{ UnknownFlags bool }{UnknownFlags: false},
+	Run: func(cmd *cobra.Command, args []string) {
+		err := e.ExecuteValidateSchemaExportCmd(cmd, args)
+		if err != nil {
+			u.LogErrorAndExit(err)
+		}
+	},
+}
+
+func init() {
+	validateSchemaExportCmd.DisableFlagParsing = false
+	validateSchemaExportCmd.PersistentFlags().String("type", "manifest", "Type of the JSON Schema to export: manifest (Atmos stack manifests) "+
+		"or config (Atmos CLI config files): atmos validate schema export --type config")
+	validateSchemaExportCmd.PersistentFlags().StringP("file", "f", "", "Write the JSON Schema to the file: atmos validate schema export -f <file>")
+
+	validateSchemaCmd.AddCommand(validateSchemaExportCmd)
+}
@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	e "github.com/cloudposse/atmos/internal/exec"
+	u "github.com/cloudposse/atmos/pkg/utils"
+)
+
+// validateSpaceliftCmd validates the Spacelift stack names and labels
+var validateSpaceliftCmd = &cobra.Command{
+	Use:   "spacelift",
+	Short: "Execute 'validate spacelift' command",
+	Long: "This command validates the Spacelift stacks generated from the Atmos stacks: it reports the duplicate Spacelift stack names, " +
+		"the names with invalid characters, the 'depends_on' dependencies on the Spacelift stacks that don't exist, and the invalid labels: atmos validate spacelift",
+	Example:            "atmos validate spacelift",
+	FParseErrWhitelist: struct{ UnknownFlags bool }{UnknownFlags: false},
+	Run: func(cmd *cobra.Command, args []string) {
+		err := e.ExecuteValidateSpaceliftCmd(cmd, args)
+		if err != nil {
+			u.LogErrorAndExit(err)
+		}
+
+		cliConfig, err := initCliConfigFromFlags(cmd)
+		if err != nil {
+			u.LogErrorAndExit(err)
+		}
+
+		u.LogInfo(cliConfig, "all Spacelift stacks validated successfully\n")
+	},
+}
+
+func init() {
+	validateSpaceliftCmd.DisableFlagParsing = false
+
+	validateSpaceliftCmd.PersistentFlags().String("stack-config-path-template", "stacks/%s.yaml",
+		"Template to build the paths to the stack manifests in the 'import', 'stack' and 'deps' labels: atmos validate spacelift --stack-config-path-template=stacks/%s.yaml",
+	)
+	validateSpaceliftCmd.PersistentFlags().Int("max-label-length", 255, "The maximum length of the Spacelift stack labels: atmos validate spacelift --max-label-length=255")
+
+	validateCmd.AddCommand(validateSpaceliftCmd)
+}
@@ -1,8 +1,6 @@
 package cmd
 
 import (
-	cfg "github.com/cloudposse/atmos/pkg/config"
-	"github.com/cloudposse/atmos/pkg/schema"
 	"github.com/spf13/cobra"
 
 	e "github.com/cloudposse/atmos/internal/exec"
@@ -22,7 +20,7 @@ var ValidateStacksCmd = &cobra.Command{
 			u.LogErrorAndExit(err)
 		}
 
-		cliConfig, err := cfg.InitCliConfig(schema.ConfigAndStacksInfo{}, false)
+		cliConfig, err := initCliConfigFromFlags(cmd)
 		if err != nil {
 			u.LogErrorAndExit(err)
 		}
@@ -35,6 +33,11 @@ func init() {
 	ValidateStacksCmd.DisableFlagParsing = false
 
 	ValidateStacksCmd.PersistentFlags().String("schemas-atmos-manifest", "", "atmos validate stacks --schemas-atmos-manifest <path-to-atmos-json-schema>")
+	ValidateStacksCmd.PersistentFlags().Bool("strict", false, "Report unknown sections in the stack manifests: atmos validate stacks --strict")
+	ValidateStacksCmd.PersistentFlags().Bool("validate", false, "Validate the 'vars' sections of the terraform components using the JSON Schemas generated "+
+		"from the components' variables: atmos validate stacks --validate")
+	ValidateStacksCmd.PersistentFlags().Bool("check-vars", false, "Report the variables in the 'vars' sections of the terraform components that are not declared in the components, "+
+		"and the required variables without values: atmos validate stacks --check-vars")
 
 	validateCmd.AddCommand(ValidateStacksCmd)
 }
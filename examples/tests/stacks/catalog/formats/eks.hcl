@@ -0,0 +1,6 @@
+# The stack manifest in HCL format, imported with the context
+components "terraform" "eks" {
+  vars = {
+    name = "eks-{{ .stage }}"
+  }
+}
@@ -0,0 +1,22 @@
+# The stack manifest in HCL format that imports the stack manifests in JSON and HCL formats
+import = [
+  "catalog/formats/vpc.json",
+  {
+    path = "catalog/formats/eks"
+    context = {
+      stage = "prod"
+    }
+  }
+]
+
+vars = {
+  stage = "prod"
+}
+
+terraform {
+  overrides = {
+    vars = {
+      region = "us-east-2"
+    }
+  }
+}
@@ -0,0 +1,8 @@
+// Shared CUE definitions that can be imported by the component schemas
+package common
+
+// CIDR block, e.g. "10.0.0.0/18"
+#CIDR: =~"^([0-9]{1,3}\\.){3}[0-9]{1,3}(/([0-9]|[1-2][0-9]|3[0-2]))?$"
+
+// AWS region, e.g. "us-east-2"
+#Region: =~"^[a-z]{2}(-gov)?-[a-z]+-[0-9]$"
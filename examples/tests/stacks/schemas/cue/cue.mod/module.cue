@@ -0,0 +1 @@
+module: "atmos.tools/schemas"
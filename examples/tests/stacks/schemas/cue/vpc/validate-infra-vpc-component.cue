@@ -0,0 +1,11 @@
+// CUE schema for infra/vpc atmos component
+package vpc
+
+import "atmos.tools/schemas/common"
+
+vars: {
+	region:                  common.#Region
+	ipv4_primary_cidr_block: common.#CIDR
+	map_public_ip_on_launch: bool
+	max_subnet_count?:       int & >=1 & <=6
+}
@@ -0,0 +1,23 @@
+# Stack policy with the 'repo' scope.
+# The input is the 'atmos describe stacks' output (all components in all stacks):
+#   <stack>:
+#     components:
+#       terraform:
+#         <component>:
+#           backend_type: ...
+
+# 'package atmos' is required in all `atmos` OPA policies
+package atmos
+
+# Backend types of all Terraform components in all stacks
+backend_types := {backend_type |
+    backend_type := input[_].components.terraform[_].backend_type
+}
+
+# All components in all stacks must use the same Terraform backend type
+errors[{
+    "msg": sprintf("all components must use the same Terraform backend type, found: %s", [concat(", ", sort(backend_types))]),
+    "rule_id": "consistent-backend-type",
+}] {
+    count(backend_types) > 1
+}
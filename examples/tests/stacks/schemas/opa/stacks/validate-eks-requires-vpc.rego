@@ -0,0 +1,26 @@
+# Stack policy with the 'stack' scope.
+# The input is the 'atmos describe stacks' output for one stack, and the stack name in the 'atmos_stack' attribute:
+#   atmos_stack: <stack>
+#   components:
+#     terraform:
+#       <component>: ...
+
+# 'package atmos' is required in all `atmos` OPA policies
+package atmos
+
+import future.keywords.in
+
+# Every stack with an EKS cluster must also have a VPC
+errors[{
+    "msg": sprintf("the stack '%s' has the EKS cluster component '%s', but does not have a VPC component", [input.atmos_stack, component]),
+    "rule_id": "eks-requires-vpc",
+}] {
+    some component, _ in input.components.terraform
+    endswith(component, "/cluster")
+    not has_vpc
+}
+
+has_vpc {
+    some component, _ in input.components.terraform
+    component in {"vpc", "infra/vpc"}
+}
@@ -0,0 +1,48 @@
+# Atmos looks for the 'errors' and 'warnings' outputs from the OPA policies.
+# The errors and warnings can be strings, or objects with the following attributes:
+#   msg      - the message (required)
+#   path     - the path to the invalid value in the component config (e.g. 'vars.name').
+#              Atmos finds the stack manifest and the line where the value is defined
+#   severity - the severity of the error: info, low, medium, high or critical
+#   rule_id  - the ID of the rule
+#
+# If the 'errors' output contains one or more errors with the severity equal to or higher than 'fail_on_severity',
+# Atmos considers the policy failed. The errors with lower severity are reported as warnings.
+# The warnings are reported, but don't fail the validation
+
+# 'package atmos' is required in all `atmos` OPA policies
+package atmos
+
+# Only the errors with 'medium' or higher severity (and the errors without severity) fail the validation
+fail_on_severity := "medium"
+
+# In 'dev', only 2 Availability Zones are allowed
+errors[{
+    "msg": "In 'dev', only 2 Availability Zones are allowed",
+    "path": "vars.availability_zones",
+    "severity": "high",
+    "rule_id": "vpc-dev-availability-zones",
+}] {
+    input.vars.stage == "dev"
+    count(input.vars.availability_zones) != 2
+}
+
+# In 'dev', NAT Gateways are not recommended (reported as a warning since the severity is lower than 'fail_on_severity')
+errors[{
+    "msg": "In 'dev', NAT Gateways are not recommended",
+    "path": "vars.nat_gateway_enabled",
+    "severity": "low",
+    "rule_id": "vpc-dev-nat-gateway",
+}] {
+    input.vars.stage == "dev"
+    input.vars.nat_gateway_enabled == true
+}
+
+# Public IPs mapped on launch are reported as a warning
+warnings[{
+    "msg": "Mapping public IPs on launch is not recommended",
+    "path": ["vars", "map_public_ip_on_launch"],
+    "rule_id": "vpc-map-public-ip-on-launch",
+}] {
+    input.vars.map_public_ip_on_launch == true
+}
@@ -0,0 +1,22 @@
+# OPA tests for the policy in the file 'validate-infra-vpc-component-structured.rego'.
+# Execute 'atmos validate test' to run the tests
+package atmos
+
+import future.keywords.in
+
+test_dev_availability_zones {
+    result := errors with input as {"vars": {"stage": "dev", "availability_zones": ["us-east-2a", "us-east-2b", "us-east-2c"]}}
+    count(result) == 1
+    some e in result
+    e.rule_id == "vpc-dev-availability-zones"
+}
+
+test_dev_two_availability_zones {
+    result := errors with input as {"vars": {"stage": "dev", "availability_zones": ["us-east-2a", "us-east-2b"]}}
+    count(result) == 0
+}
+
+test_map_public_ip_on_launch_warning {
+    result := warnings with input as {"vars": {"map_public_ip_on_launch": true}}
+    count(result) == 1
+}
@@ -100,6 +100,11 @@ func ExecuteAtlantisGenerateRepoConfigCmd(cmd *cobra.Command, args []string) err
 		return err
 	}
 
+	withFiles, err := flags.GetBool("with-files")
+	if err != nil {
+		return err
+	}
+
 	// If the flag `--affected-only=true` is passed, find the affected components and stacks
 	if affectedOnly {
 		return ExecuteAtlantisGenerateRepoConfigAffectedOnly(
@@ -113,6 +118,7 @@ func ExecuteAtlantisGenerateRepoConfigCmd(cmd *cobra.Command, args []string) err
 			sshKeyPath,
 			sshKeyPassword,
 			verbose,
+			withFiles,
 		)
 	}
 
@@ -123,6 +129,7 @@ func ExecuteAtlantisGenerateRepoConfigCmd(cmd *cobra.Command, args []string) err
 		projectTemplateName,
 		stacks,
 		components,
+		withFiles,
 	)
 }
 
@@ -138,6 +145,7 @@ func ExecuteAtlantisGenerateRepoConfigAffectedOnly(
 	sshKeyPath string,
 	sshKeyPassword string,
 	verbose bool,
+	withFiles bool,
 ) error {
 	if repoPath != "" && (ref != "" || sha != "" || sshKeyPath != "" || sshKeyPassword != "") {
 		return errors.New("if the '--repo-path' flag is specified, the '--ref', '--sha', '--ssh-key' and '--ssh-key-password' flags can't be used")
@@ -181,10 +189,12 @@ func ExecuteAtlantisGenerateRepoConfigAffectedOnly(
 		projectTemplateName,
 		affectedStacks,
 		affectedComponents,
+		withFiles,
 	)
 }
 
-// ExecuteAtlantisGenerateRepoConfig generates repository configuration for Atlantis
+// ExecuteAtlantisGenerateRepoConfig generates repository configuration for Atlantis.
+// If `withFiles` is `true`, it also writes the varfile and the backend config for each Atlantis project
 func ExecuteAtlantisGenerateRepoConfig(
 	cliConfig schema.CliConfiguration,
 	outputPath string,
@@ -192,6 +202,7 @@ func ExecuteAtlantisGenerateRepoConfig(
 	projectTemplateNameArg string,
 	stacks []string,
 	components []string,
+	withFiles bool,
 ) error {
 
 	stacksMap, _, err := FindStacksMap(cliConfig, false)
@@ -215,6 +226,18 @@ func ExecuteAtlantisGenerateRepoConfig(
 		}
 	}
 
+	// The project template passed on the command line is used to build the names of all projects, including the projects in `depends_on`
+	projectTemplateArg := projectTemplate
+
+	// All terraform components in all stacks, to find the Atlantis projects for the components in `settings.depends_on`
+	stackComponents, err := findAtlantisStackComponents(cliConfig, stacksMap)
+	if err != nil {
+		return err
+	}
+
+	// The backend types written to the `backend.tf.json` files in the terraform component folders (if `withFiles` is `true`)
+	backendTypes := map[string]string{}
+
 	// Iterate over all components in all stacks and generate atlantis projects
 	// Iterate not over the map itself, but over the sorted map keys since Go iterates over maps in random order
 	stacksMapSortedKeys := u.StringKeysFromMap(stacksMap)
@@ -258,6 +281,10 @@ func ExecuteAtlantisGenerateRepoConfig(
 							continue
 						}
 					}
+					// Don't include disabled components
+					if !IsComponentEnabled(metadataSection, varsSection) {
+						continue
+					}
 				}
 
 				// If the project template is not passes on the command line, find and process it in the component project template in the 'settings.atlantis' section
@@ -346,6 +373,8 @@ func ExecuteAtlantisGenerateRepoConfig(
 					// atmos terraform generate varfiles --stacks=tenant1-ue2-staging,tenant1-ue2-prod
 					u.SliceContainsString(stacks, contextPrefix) {
 
+					componentSettingsSection, _ := componentSection["settings"].(map[any]any)
+
 					// Generate an atlantis project for the component in the stack
 					// Replace the context tokens
 					var whenModified []string
@@ -355,30 +384,100 @@ func ExecuteAtlantisGenerateRepoConfig(
 						whenModified = append(whenModified, processedItem)
 					}
 
+					// Add the files that the component depends on (local modules, `settings.depends_on` files and folders,
+					// the stack manifest and its imports), so Atlantis plans the project when any of them changes
+					stackImports, _ := stackSection.(map[any]any)["imports"].([]string)
+
+					dependenciesWhenModified, err := buildAtlantisProjectWhenModified(
+						cliConfig,
+						stackConfigFileName,
+						stackImports,
+						terraformComponentPath,
+						componentSettingsSection,
+					)
+					if err != nil {
+						return err
+					}
+
 					atlantisProjectAutoplanConfig := schema.AtlantisProjectAutoplanConfig{
 						Enabled:      projectTemplate.Autoplan.Enabled,
-						WhenModified: whenModified,
+						WhenModified: u.UniqueStrings(append(whenModified, dependenciesWhenModified...)),
 					}
 
 					atlantisProjectName := BuildAtlantisProjectName(context, projectTemplate.Name)
 
 					atlantisProject := schema.AtlantisProjectConfig{
 						Name:                      atlantisProjectName,
+						Branch:                    projectTemplate.Branch,
 						Workspace:                 cfg.ReplaceContextTokens(context, projectTemplate.Workspace),
 						Dir:                       cfg.ReplaceContextTokens(context, projectTemplate.Dir),
 						TerraformVersion:          projectTemplate.TerraformVersion,
+						TerraformDistribution:     projectTemplate.TerraformDistribution,
 						DeleteSourceBranchOnMerge: projectTemplate.DeleteSourceBranchOnMerge,
 						Autoplan:                  atlantisProjectAutoplanConfig,
+						PlanRequirements:          projectTemplate.PlanRequirements,
 						ApplyRequirements:         projectTemplate.ApplyRequirements,
+						ImportRequirements:        projectTemplate.ImportRequirements,
+						RepoLocking:               projectTemplate.RepoLocking,
+						RepoLocks:                 projectTemplate.RepoLocks,
+						CustomPolicyCheck:         projectTemplate.CustomPolicyCheck,
+						PolicyCheck:               projectTemplate.PolicyCheck,
+						SilencePRComments:         projectTemplate.SilencePRComments,
+						ExecutionOrderGroup:       projectTemplate.ExecutionOrderGroup,
 						Workflow:                  projectTemplate.Workflow,
+						Extra:                     projectTemplate.Extra,
+					}
+
+					// Translate the components from the 'settings.depends_on' section into the Atlantis project names,
+					// and add them to the projects from the 'depends_on' section of the project template
+					dependsOn, err := buildAtlantisProjectDependsOn(
+						cliConfig,
+						stackComponents,
+						projectTemplateArg,
+						stackConfigFileName,
+						componentName,
+						context,
+						componentSettingsSection,
+					)
+					if err != nil {
+						return err
+					}
+
+					for _, item := range projectTemplate.DependsOn {
+						dependsOn = append(dependsOn, cfg.ReplaceContextTokens(context, item))
 					}
 
+					atlantisProject.DependsOn = u.UniqueStrings(dependsOn)
+
 					atlantisProjects = append(atlantisProjects, atlantisProject)
+
+					// Write the varfile and the backend config for the project, so Atlantis workflows don't need to call Atmos for each project
+					if withFiles {
+						err = writeAtlantisProjectFiles(
+							cliConfig,
+							stackConfigFileName,
+							atlantisProject.Name,
+							componentName,
+							terraformComponent,
+							contextPrefix,
+							componentSection,
+							backendTypes,
+						)
+						if err != nil {
+							return err
+						}
+					}
 				}
 			}
 		}
 	}
 
+	// Assign the execution order groups from the dependencies between the projects, so Atlantis applies the dependencies first
+	err = setAtlantisProjectsExecutionOrderGroups(cliConfig, atlantisProjects)
+	if err != nil {
+		return err
+	}
+
 	// If the config template is not passes on the command line, find and process it in the component project template in the 'settings.atlantis' section
 	if configTemplateNameArg == "" {
 		if settingsSection, ok = componentSection["settings"].(map[any]any); ok {
@@ -423,6 +522,13 @@ func ExecuteAtlantisGenerateRepoConfig(
 	atlantisYaml.ParallelPlan = configTemplate.ParallelPlan
 	atlantisYaml.ParallelApply = configTemplate.ParallelApply
 	atlantisYaml.AllowedRegexpPrefixes = configTemplate.AllowedRegexpPrefixes
+	atlantisYaml.Autodiscover = configTemplate.Autodiscover
+	atlantisYaml.AbortOnExecutionOrderFail = configTemplate.AbortOnExecutionOrderFail
+	atlantisYaml.AllowedOverrides = configTemplate.AllowedOverrides
+	atlantisYaml.AllowedWorkflows = configTemplate.AllowedWorkflows
+	atlantisYaml.AllowCustomWorkflows = configTemplate.AllowCustomWorkflows
+	atlantisYaml.Policies = configTemplate.Policies
+	atlantisYaml.Extra = configTemplate.Extra
 	atlantisYaml.Projects = atlantisProjects
 
 	// Workflows
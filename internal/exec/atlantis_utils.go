@@ -1,13 +1,19 @@
 package exec
 
 import (
+	"fmt"
+	"path"
+	"path/filepath"
 	"reflect"
+	"sort"
 	"strings"
 
 	"github.com/mitchellh/mapstructure"
+	"github.com/samber/lo"
 
 	cfg "github.com/cloudposse/atmos/pkg/config"
 	"github.com/cloudposse/atmos/pkg/schema"
+	u "github.com/cloudposse/atmos/pkg/utils"
 )
 
 // BuildAtlantisProjectName builds an Atlantis project name from the provided context and project name pattern
@@ -50,3 +56,359 @@ func BuildAtlantisProjectNameFromComponentConfig(
 
 	return atlantisProjectName, nil
 }
+
+// atlantisStackComponent is a terraform component in a stack that an Atlantis project can depend on
+type atlantisStackComponent struct {
+	settingsSection map[any]any
+	varsSection     map[any]any
+}
+
+// findAtlantisStackComponents returns the terraform components in all stacks, keyed by the stack name and the component name
+func findAtlantisStackComponents(cliConfig schema.CliConfiguration, stacksMap map[string]any) (map[string]map[string]atlantisStackComponent, error) {
+	result := map[string]map[string]atlantisStackComponent{}
+
+	for stackConfigFileName, stackSection := range stacksMap {
+		componentsSection, ok := stackSection.(map[any]any)["components"].(map[string]any)
+		if !ok {
+			continue
+		}
+
+		terraformSection, ok := componentsSection["terraform"].(map[string]any)
+		if !ok {
+			continue
+		}
+
+		for componentName, compSection := range terraformSection {
+			componentSection, ok := compSection.(map[string]any)
+			if !ok {
+				continue
+			}
+
+			varsSection, ok := componentSection["vars"].(map[any]any)
+			if !ok {
+				continue
+			}
+
+			settingsSection, _ := componentSection["settings"].(map[any]any)
+
+			stackName, err := componentStackName(cliConfig, stackConfigFileName, cfg.GetContextFromVars(varsSection))
+			if err != nil {
+				return nil, err
+			}
+
+			if _, ok := result[stackName]; !ok {
+				result[stackName] = map[string]atlantisStackComponent{}
+			}
+
+			result[stackName][componentName] = atlantisStackComponent{
+				settingsSection: settingsSection,
+				varsSection:     varsSection,
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// buildAtlantisProjectDependsOn returns the names of the Atlantis projects for the components from the `settings.depends_on` section
+// of the component. If the `context` (namespace, tenant, environment, stage) is not provided in `depends_on`,
+// the dependency is in the same context as the component.
+// If the project template is passed on the command line, it's used to build the names of the projects, otherwise the names are built
+// from the project templates in the `settings.atlantis` section of the dependencies
+func buildAtlantisProjectDependsOn(
+	cliConfig schema.CliConfiguration,
+	stackComponents map[string]map[string]atlantisStackComponent,
+	projectTemplateArg schema.AtlantisProjectConfig,
+	stackConfigFileName string,
+	componentName string,
+	context schema.Context,
+	settingsSection map[any]any,
+) ([]string, error) {
+	var settings schema.Settings
+	if err := mapstructure.Decode(settingsSection, &settings); err != nil {
+		return nil, err
+	}
+
+	var result []string
+
+	for _, dependsOn := range settings.DependsOn {
+		// `file` and `folder` dependencies don't have Atlantis projects
+		if dependsOn.Component == "" {
+			continue
+		}
+
+		dependsOn = dependsOnContext(dependsOn, context)
+
+		stackName, err := componentStackName(cliConfig, stackConfigFileName, dependsOn)
+		if err != nil {
+			return nil, err
+		}
+
+		currentStackName, err := componentStackName(cliConfig, stackConfigFileName, context)
+		if err != nil {
+			return nil, err
+		}
+
+		dependency, ok := stackComponents[stackName][dependsOn.Component]
+		if !ok {
+			return nil, fmt.Errorf("the component '%[1]s' in the stack '%[2]s' specifies 'settings.depends_on' dependency "+
+				"on the component '%[3]s' in the stack '%[4]s', but '%[3]s' is not defined in the '%[4]s' stack, or the component and stack names are not correct",
+				componentName,
+				currentStackName,
+				dependsOn.Component,
+				stackName,
+			)
+		}
+
+		var projectName string
+
+		if projectTemplateArg.Name != "" {
+			dependencyContext := cfg.GetContextFromVars(dependency.varsSection)
+			dependencyContext.Component = strings.Replace(dependsOn.Component, "/", "-", -1)
+			projectName = BuildAtlantisProjectName(dependencyContext, projectTemplateArg.Name)
+		} else {
+			projectName, err = BuildAtlantisProjectNameFromComponentConfig(cliConfig, dependsOn.Component, dependency.settingsSection, dependency.varsSection)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if projectName != "" {
+			result = append(result, projectName)
+		}
+	}
+
+	result = u.UniqueStrings(result)
+	sort.Strings(result)
+
+	return result, nil
+}
+
+// buildAtlantisProjectWhenModified returns the `autoplan.when_modified` patterns for the files that the component depends on:
+// the Terraform files in the component folder, the local Terraform modules that the component calls, the files and folders
+// from the `settings.depends_on` section, and the stack manifest with all its imports.
+// Atlantis resolves the patterns relative to the project `dir`, so all the paths are relative to the component folder
+func buildAtlantisProjectWhenModified(
+	cliConfig schema.CliConfiguration,
+	stackConfigFileName string,
+	stackImports []string,
+	componentPath string,
+	settingsSection map[any]any,
+) ([]string, error) {
+	componentPathAbs, err := filepath.Abs(componentPath)
+	if err != nil {
+		return nil, err
+	}
+
+	relativePattern := func(p string, suffix string) (string, error) {
+		pAbs, err := filepath.Abs(p)
+		if err != nil {
+			return "", err
+		}
+		rel, err := filepath.Rel(componentPathAbs, pAbs)
+		if err != nil {
+			return "", err
+		}
+		return filepath.ToSlash(rel) + suffix, nil
+	}
+
+	// Component folder
+	result := []string{"**/*.tf*"}
+
+	// Local Terraform modules
+	modulePaths, err := findTerraformComponentLocalModules(componentPathAbs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, modulePath := range modulePaths {
+		pattern, err := relativePattern(modulePath, "/**/*.tf*")
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, pattern)
+	}
+
+	// Files and folders from `settings.depends_on`
+	var settings schema.Settings
+	if err = mapstructure.Decode(settingsSection, &settings); err != nil {
+		return nil, err
+	}
+
+	for _, dependsOn := range settings.DependsOn {
+		var pattern string
+		if dependsOn.File != "" {
+			pattern, err = relativePattern(dependsOn.File, "")
+		} else if dependsOn.Folder != "" {
+			pattern, err = relativePattern(dependsOn.Folder, "/**")
+		} else {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, pattern)
+	}
+
+	// Stack manifest and all its imports
+	stackManifests := append([]string{stackConfigFileName}, stackImports...)
+
+	for _, stackManifest := range stackManifests {
+		stackManifestFiles, err := cfg.GetStackConfigFileGlobMatches(path.Join(cliConfig.StacksBaseAbsolutePath, stackManifest))
+		if err != nil || len(stackManifestFiles) == 0 {
+			u.LogDebug(cliConfig, fmt.Sprintf("Not adding the stack manifest '%s' to 'when_modified': the file was not found in the stacks folder '%s'",
+				stackManifest, cliConfig.StacksBaseAbsolutePath))
+			continue
+		}
+
+		pattern, err := relativePattern(stackManifestFiles[0], "")
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, pattern)
+	}
+
+	return u.UniqueStrings(result), nil
+}
+
+// writeAtlantisProjectFiles writes the varfile and the backend config for the Atlantis project to the terraform component folder.
+// The Atlantis projects for the same terraform component share the folder, so the backend config of each project is written to its own
+// `<project>.backend.tfbackend` file (passed to `terraform init -backend-config`), and the shared `backend.tf.json` file
+// declares only the backend type (the partial backend configuration)
+func writeAtlantisProjectFiles(
+	cliConfig schema.CliConfiguration,
+	stackConfigFileName string,
+	projectName string,
+	componentName string,
+	terraformComponent string,
+	contextPrefix string,
+	componentSection map[string]any,
+	backendTypes map[string]string,
+) error {
+	info := schema.ConfigAndStacksInfo{
+		ComponentFromArg: componentName,
+		ContextPrefix:    contextPrefix,
+	}
+
+	// Process the component path and name in the same way as `ProcessStacks` does
+	componentPathParts := strings.Split(componentName, "/")
+	info.Component = componentPathParts[len(componentPathParts)-1]
+	info.ComponentFolderPrefix = strings.Join(componentPathParts[:len(componentPathParts)-1], "/")
+	info.FinalComponent = info.Component
+
+	if terraformComponent != componentName {
+		baseComponentPathParts := strings.Split(terraformComponent, "/")
+		info.BaseComponent = baseComponentPathParts[len(baseComponentPathParts)-1]
+		info.ComponentFolderPrefix = strings.Join(baseComponentPathParts[:len(baseComponentPathParts)-1], "/")
+		info.FinalComponent = info.BaseComponent
+	}
+
+	info.ComponentFolderPrefixReplaced = strings.Replace(info.ComponentFolderPrefix, "/", "-", -1)
+
+	// Varfile
+	varsSection, _ := componentSection["vars"].(map[any]any)
+	varFilePath := constructTerraformComponentVarfilePath(cliConfig, info)
+
+	u.LogDebug(cliConfig, fmt.Sprintf("Writing the variables for the component '%s' in the stack '%s' to the file '%s'", componentName, contextPrefix, varFilePath))
+
+	if err := u.WriteToFileAsJSON(varFilePath, varsSection, 0644); err != nil {
+		return err
+	}
+
+	// Backend config
+	backendSection, ok := componentSection["backend"].(map[any]any)
+	if !ok {
+		return nil
+	}
+
+	backendType, ok := componentSection["backend_type"].(string)
+	if !ok || backendType == "" {
+		return nil
+	}
+
+	workingDir := constructTerraformComponentWorkingDir(cliConfig, info)
+	backendConfigFilePath := path.Join(workingDir, projectName+".backend.tfbackend")
+
+	u.LogDebug(cliConfig, fmt.Sprintf("Writing the backend config for the Atlantis project '%s' to the file '%s'", projectName, backendConfigFilePath))
+
+	if err := u.WriteToFileAsHcl(cliConfig, backendConfigFilePath, backendSection, 0644); err != nil {
+		return err
+	}
+
+	backendFilePath := path.Join(workingDir, "backend.tf.json")
+
+	if writtenBackendType, ok := backendTypes[backendFilePath]; ok {
+		if writtenBackendType != backendType {
+			u.LogWarning(cliConfig, fmt.Sprintf("the component '%s' in the stack config file '%s' uses the backend type '%s', "+
+				"but the file '%s' shared by the Atlantis projects for the terraform component '%s' declares the backend type '%s'",
+				componentName, stackConfigFileName, backendType, backendFilePath, terraformComponent, writtenBackendType))
+		}
+		return nil
+	}
+
+	backendTypes[backendFilePath] = backendType
+
+	u.LogDebug(cliConfig, fmt.Sprintf("Writing the backend type for the terraform component '%s' to the file '%s'", terraformComponent, backendFilePath))
+
+	return u.WriteToFileAsJSON(backendFilePath, generateComponentBackendConfig(backendType, map[any]any{}), 0644)
+}
+
+// setAtlantisProjectsExecutionOrderGroups removes the dependencies that are not in the list of the Atlantis projects
+// (e.g. abstract, disabled, or filtered out components), and assigns the `execution_order_group` to each project from a topological sort
+// of the dependencies: the projects without dependencies are in the group `0` (or the group from the project template),
+// and each project is in the group after all its dependencies
+func setAtlantisProjectsExecutionOrderGroups(cliConfig schema.CliConfiguration, projects []schema.AtlantisProjectConfig) error {
+	projectIndexes := map[string]int{}
+	for i, p := range projects {
+		projectIndexes[p.Name] = i
+	}
+
+	for i, p := range projects {
+		var dependsOn []string
+		for _, d := range p.DependsOn {
+			if _, ok := projectIndexes[d]; ok {
+				dependsOn = append(dependsOn, d)
+			} else {
+				u.LogDebug(cliConfig, fmt.Sprintf("The Atlantis project '%s' depends on the project '%s' that is not in the Atlantis config", p.Name, d))
+			}
+		}
+		projects[i].DependsOn = dependsOn
+	}
+
+	groups := map[string]int{}
+	var visit func(name string, path []string) (int, error)
+
+	visit = func(name string, path []string) (int, error) {
+		if group, ok := groups[name]; ok {
+			return group, nil
+		}
+
+		if lo.Contains(path, name) {
+			return 0, fmt.Errorf("circular dependency between the Atlantis projects: %s", strings.Join(append(path, name), " -> "))
+		}
+
+		group := projects[projectIndexes[name]].ExecutionOrderGroup
+		for _, d := range projects[projectIndexes[name]].DependsOn {
+			dependencyGroup, err := visit(d, append(path, name))
+			if err != nil {
+				return 0, err
+			}
+			if dependencyGroup+1 > group {
+				group = dependencyGroup + 1
+			}
+		}
+
+		groups[name] = group
+		return group, nil
+	}
+
+	for i, p := range projects {
+		group, err := visit(p.Name, nil)
+		if err != nil {
+			return err
+		}
+		projects[i].ExecutionOrderGroup = group
+	}
+
+	return nil
+}
@@ -0,0 +1,633 @@
+package exec
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	cfg "github.com/cloudposse/atmos/pkg/config"
+	"github.com/cloudposse/atmos/pkg/schema"
+	u "github.com/cloudposse/atmos/pkg/utils"
+)
+
+const (
+	ciProviderGitLab    = "gitlab"
+	ciProviderBuildkite = "buildkite"
+
+	// ciDefaultJobName is the default job name template
+	ciDefaultJobName = "{stack}-{component}"
+)
+
+// invalidCIJobNameChars matches the characters that are not allowed in the Buildkite step keys
+var invalidCIJobNameChars = regexp.MustCompile(`[^a-zA-Z0-9_:-]`)
+
+// ciJob is a plan/apply job pair for a terraform component in a stack
+type ciJob struct {
+	stack     string
+	component string
+	name      string
+	plan      map[string]any
+	apply     map[string]any
+	manual    bool
+	dependsOn []string
+}
+
+// ExecuteCIGenerateCmd executes `ci generate` command
+func ExecuteCIGenerateCmd(cmd *cobra.Command, args []string) error {
+	info, err := processCommandLineArgs("", cmd, args, nil)
+	if err != nil {
+		return err
+	}
+
+	cliConfig, err := cfg.InitCliConfig(info, true)
+	if err != nil {
+		return err
+	}
+
+	flags := cmd.Flags()
+
+	provider, err := flags.GetString("provider")
+	if err != nil {
+		return err
+	}
+
+	outputPath, err := flags.GetString("output-path")
+	if err != nil {
+		return err
+	}
+
+	pipelineTemplateName, err := flags.GetString("pipeline-template")
+	if err != nil {
+		return err
+	}
+
+	jobTemplateName, err := flags.GetString("job-template")
+	if err != nil {
+		return err
+	}
+
+	stacksCsv, err := flags.GetString("stacks")
+	if err != nil {
+		return err
+	}
+	var stacks []string
+	if stacksCsv != "" {
+		stacks = strings.Split(stacksCsv, ",")
+	}
+
+	componentsCsv, err := flags.GetString("components")
+	if err != nil {
+		return err
+	}
+	var components []string
+	if componentsCsv != "" {
+		components = strings.Split(componentsCsv, ",")
+	}
+
+	affectedOnly, err := flags.GetBool("affected-only")
+	if err != nil {
+		return err
+	}
+
+	ref, err := flags.GetString("ref")
+	if err != nil {
+		return err
+	}
+
+	sha, err := flags.GetString("sha")
+	if err != nil {
+		return err
+	}
+
+	repoPath, err := flags.GetString("repo-path")
+	if err != nil {
+		return err
+	}
+
+	sshKeyPath, err := flags.GetString("ssh-key")
+	if err != nil {
+		return err
+	}
+
+	sshKeyPassword, err := flags.GetString("ssh-key-password")
+	if err != nil {
+		return err
+	}
+
+	verbose, err := flags.GetBool("verbose")
+	if err != nil {
+		return err
+	}
+
+	// If the flag `--affected-only=true` is passed, find the affected components and stacks
+	var affected []schema.Affected
+
+	if affectedOnly {
+		if repoPath != "" && (ref != "" || sha != "" || sshKeyPath != "" || sshKeyPassword != "") {
+			return errors.New("if the '--repo-path' flag is specified, the '--ref', '--sha', '--ssh-key' and '--ssh-key-password' flags can't be used")
+		}
+
+		if repoPath == "" {
+			affected, err = ExecuteDescribeAffectedWithTargetRepoClone(cliConfig, ref, sha, sshKeyPath, sshKeyPassword, verbose, false)
+		} else {
+			affected, err = ExecuteDescribeAffectedWithTargetRepoPath(cliConfig, repoPath, verbose, false)
+		}
+		if err != nil {
+			return err
+		}
+
+		// No affected components (`nil` would mean all components)
+		if affected == nil {
+			affected = []schema.Affected{}
+		}
+	}
+
+	pipeline, err := ExecuteCIGenerate(cliConfig, provider, pipelineTemplateName, jobTemplateName, stacks, components, affected)
+	if err != nil {
+		return err
+	}
+
+	// Write the pipeline to a file at the specified path
+	// Check the command line argument '--output-path' first
+	// Then check the 'integrations.<provider>.path' setting in 'atmos.yaml'
+	fileName := outputPath
+	if fileName == "" {
+		fileName = ciIntegration(cliConfig, provider).Path
+		u.LogDebug(cliConfig, fmt.Sprintf("Using 'integrations.%s.path: %s' from 'atmos.yaml'", provider, fileName))
+	} else {
+		u.LogDebug(cliConfig, fmt.Sprintf("Using '--output-path %s' command-line argument", fileName))
+	}
+
+	// If the path is empty, dump to 'stdout'
+	if fileName == "" {
+		return u.PrintAsYAML(pipeline)
+	}
+
+	u.LogDebug(cliConfig, fmt.Sprintf("Writing %s pipeline to '%s'\n", provider, fileName))
+
+	fileAbsolutePath, err := filepath.Abs(fileName)
+	if err != nil {
+		return err
+	}
+
+	// Create all the intermediate subdirectories
+	err = u.EnsureDir(fileAbsolutePath)
+	if err != nil {
+		return err
+	}
+
+	return u.WriteToFileAsYAML(fileAbsolutePath, pipeline, 0644)
+}
+
+// ExecuteCIGenerate generates the GitLab CI or Buildkite pipeline with a plan job and an apply job for each terraform component in each stack.
+// The `stacks` and `components` filters select the stacks and components. If `affected` is not `nil`, only the affected terraform components
+// are included. The apply jobs depend on the apply jobs of the components from the `settings.depends_on` section
+func ExecuteCIGenerate(
+	cliConfig schema.CliConfiguration,
+	provider string,
+	pipelineTemplateName string,
+	jobTemplateNameArg string,
+	stacks []string,
+	components []string,
+	affected []schema.Affected,
+) (map[string]any, error) {
+	if provider != ciProviderGitLab && provider != ciProviderBuildkite {
+		return nil, fmt.Errorf("invalid '--provider' flag '%s'. Valid values are '%s' and '%s'", provider, ciProviderGitLab, ciProviderBuildkite)
+	}
+
+	integration := ciIntegration(cliConfig, provider)
+
+	pipelineTemplate := map[string]any{}
+	if pipelineTemplateName != "" {
+		var ok bool
+		if pipelineTemplate, ok = integration.PipelineTemplates[pipelineTemplateName]; !ok {
+			return nil, errors.Errorf("%s pipeline template '%s' is not defined in 'integrations.%s.pipeline_templates' in 'atmos.yaml'",
+				provider, pipelineTemplateName, provider)
+		}
+	}
+
+	var jobTemplateArg schema.CIJobTemplate
+	if jobTemplateNameArg != "" {
+		var ok bool
+		if jobTemplateArg, ok = integration.JobTemplates[jobTemplateNameArg]; !ok {
+			return nil, errors.Errorf("%s job template '%s' is not defined in 'integrations.%s.job_templates' in 'atmos.yaml'",
+				provider, jobTemplateNameArg, provider)
+		}
+	}
+
+	var affectedComponents map[string]bool
+	if affected != nil {
+		affectedComponents = map[string]bool{}
+		for _, a := range affected {
+			if a.ComponentType == "terraform" {
+				affectedComponents[a.Stack+"/"+a.Component] = true
+			}
+		}
+	}
+
+	stacksMap, _, err := FindStacksMap(cliConfig, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var jobs []*ciJob
+
+	// Iterate not over the map itself, but over the sorted map keys since Go iterates over maps in random order
+	for _, stackConfigFileName := range u.StringKeysFromMap(stacksMap) {
+		componentsSection, ok := stacksMap[stackConfigFileName].(map[any]any)["components"].(map[string]any)
+		if !ok {
+			continue
+		}
+
+		terraformSection, ok := componentsSection["terraform"].(map[string]any)
+		if !ok {
+			continue
+		}
+
+		for _, componentName := range u.StringKeysFromMap(terraformSection) {
+			componentSection, ok := terraformSection[componentName].(map[string]any)
+			if !ok {
+				continue
+			}
+
+			// Check if 'components' filter is provided
+			if len(components) > 0 && !u.SliceContainsString(components, componentName) {
+				continue
+			}
+
+			varsSection, ok := componentSection["vars"].(map[any]any)
+			if !ok {
+				continue
+			}
+
+			// Don't include abstract and disabled components
+			metadataSection, _ := componentSection["metadata"].(map[any]any)
+			if componentType, ok := metadataSection["type"].(string); ok && componentType == "abstract" {
+				continue
+			}
+			if !IsComponentEnabled(metadataSection, varsSection) {
+				continue
+			}
+
+			context := cfg.GetContextFromVars(varsSection)
+
+			stackName, err := componentStackName(cliConfig, stackConfigFileName, context)
+			if err != nil {
+				return nil, err
+			}
+
+			// Check if 'stacks' filter is provided
+			// The filter can contain the names of the top-level stack manifests and the logical stack names
+			if len(stacks) > 0 && !u.SliceContainsString(stacks, stackConfigFileName) && !u.SliceContainsString(stacks, stackName) {
+				continue
+			}
+
+			if affectedComponents != nil && !affectedComponents[stackName+"/"+componentName] {
+				continue
+			}
+
+			settingsSection, _ := componentSection["settings"].(map[any]any)
+
+			jobTemplate := jobTemplateArg
+			if jobTemplateNameArg == "" {
+				jobTemplate, err = findCIJobTemplate(cliConfig, provider, stackConfigFileName, componentName, settingsSection)
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			// Find the terraform component
+			// If 'component' attribute is present, it's the terraform component
+			// Otherwise, the Atmos component name is the terraform component (by default)
+			terraformComponent := componentName
+			if componentAttribute, ok := componentSection["component"].(string); ok {
+				terraformComponent = componentAttribute
+			}
+
+			context.Component = strings.Replace(componentName, "/", "-", -1)
+			context.ComponentPath = path.Join(cliConfig.Components.Terraform.BasePath, terraformComponent)
+
+			// Base component is required to calculate terraform workspace for derived components
+			if terraformComponent != componentName {
+				context.BaseComponent = terraformComponent
+			}
+
+			workspace, err := BuildTerraformWorkspace(stackConfigFileName, cliConfig.Stacks.NamePattern, metadataSection, context)
+			if err != nil {
+				return nil, err
+			}
+			context.Workspace = workspace
+
+			jobName := jobTemplate.Name
+			if jobName == "" {
+				jobName = ciDefaultJobName
+			}
+
+			job := &ciJob{
+				stack:     stackName,
+				component: componentName,
+				name:      invalidCIJobNameChars.ReplaceAllString(replaceCITemplateTokens(context, stackName, jobName).(string), "-"),
+				plan:      replaceCITemplateTokens(context, stackName, jobTemplate.Plan).(map[string]any),
+				apply:     replaceCITemplateTokens(context, stackName, jobTemplate.Apply).(map[string]any),
+				manual:    jobTemplate.ManualApply == nil || *jobTemplate.ManualApply,
+			}
+
+			job.dependsOn, err = findSettingsDependsOnComponents(cliConfig, stackConfigFileName, context, settingsSection)
+			if err != nil {
+				return nil, err
+			}
+
+			jobs = append(jobs, job)
+		}
+	}
+
+	// The job names of the components in the pipeline, to find the jobs of the dependencies
+	jobNames := map[string]string{}
+	seen := map[string]string{}
+
+	for _, job := range jobs {
+		jobNames[job.stack+"/"+job.component] = job.name
+
+		if other, ok := seen[job.name]; ok {
+			return nil, fmt.Errorf("the %s job name '%s' is the same for the component '%s' in the stack '%s' and the component %s. "+
+				"Use the '{stack}' and '{component}' tokens in the 'name' of the job template to make the job names unique",
+				provider, job.name, job.component, job.stack, other)
+		}
+		seen[job.name] = fmt.Sprintf("'%s' in the stack '%s'", job.component, job.stack)
+	}
+
+	if provider == ciProviderGitLab {
+		return buildGitLabPipeline(pipelineTemplate, jobs, jobNames), nil
+	}
+	return buildBuildkitePipeline(pipelineTemplate, jobs, jobNames), nil
+}
+
+// ciIntegration returns the CI pipeline integration config of the provider from `atmos.yaml`
+func ciIntegration(cliConfig schema.CliConfiguration, provider string) schema.CIPipeline {
+	if provider == ciProviderBuildkite {
+		return cliConfig.Integrations.Buildkite
+	}
+	return cliConfig.Integrations.GitLab
+}
+
+// findCIJobTemplate finds the job template of the component in the `settings.<provider>.job_template` section,
+// or by the name in the `settings.<provider>.job_template_name` section
+func findCIJobTemplate(
+	cliConfig schema.CliConfiguration,
+	provider string,
+	stackConfigFileName string,
+	componentName string,
+	settingsSection map[any]any,
+) (schema.CIJobTemplate, error) {
+	var jobTemplate schema.CIJobTemplate
+
+	if settingsProviderSection, ok := settingsSection[provider].(map[any]any); ok {
+		// 'settings.<provider>.job_template' has higher priority than 'settings.<provider>.job_template_name'
+		if settingsJobTemplate, ok := settingsProviderSection["job_template"].(map[any]any); ok {
+			if err := mapstructure.Decode(settingsJobTemplate, &jobTemplate); err != nil {
+				return jobTemplate, err
+			}
+		} else if settingsJobTemplateName, ok := settingsProviderSection["job_template_name"].(string); ok && settingsJobTemplateName != "" {
+			if jobTemplate, ok = ciIntegration(cliConfig, provider).JobTemplates[settingsJobTemplateName]; !ok {
+				return jobTemplate, errors.Errorf(
+					"the component '%[1]s' in the stack config file '%[2]s' "+
+						"specifies the %[3]s job template name '%[4]s' "+
+						"in the 'settings.%[3]s.job_template_name' section, "+
+						"but this %[3]s job template is not defined in 'integrations.%[3]s.job_templates' in 'atmos.yaml'",
+					componentName, stackConfigFileName, provider, settingsJobTemplateName)
+			}
+		}
+	}
+
+	if reflect.ValueOf(jobTemplate).IsZero() {
+		return jobTemplate, errors.Errorf(
+			"%[1]s job template is not specified for the component '%[2]s'. "+
+				"In needs to be defined in one of these places: 'settings.%[1]s.job_template_name' stack config section, "+
+				"'settings.%[1]s.job_template' stack config section, "+
+				"or passed on the command line using the '--job-template' flag to select a job template from the "+
+				"collection of templates defined in the 'integrations.%[1]s.job_templates' section in 'atmos.yaml'",
+			provider, componentName)
+	}
+
+	return jobTemplate, nil
+}
+
+// findSettingsDependsOnComponents returns the `<stack>/<component>` keys of the components from the `settings.depends_on` section of the component.
+// If the context (namespace, tenant, environment, stage) is not provided in `depends_on`, the dependency is in the same context as the component
+func findSettingsDependsOnComponents(
+	cliConfig schema.CliConfiguration,
+	stackConfigFileName string,
+	context schema.Context,
+	settingsSection map[any]any,
+) ([]string, error) {
+	var settings schema.Settings
+	if err := mapstructure.Decode(settingsSection, &settings); err != nil {
+		return nil, err
+	}
+
+	var result []string
+
+	for _, dependsOn := range settings.DependsOn {
+		// Skip the `file` and `folder` dependencies
+		if dependsOn.Component == "" {
+			continue
+		}
+
+		dependsOn = dependsOnContext(dependsOn, context)
+
+		stackName, err := componentStackName(cliConfig, stackConfigFileName, dependsOn)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, stackName+"/"+dependsOn.Component)
+	}
+
+	return u.UniqueStrings(result), nil
+}
+
+// replaceCITemplateTokens replaces the context tokens and the `{stack}` token in all strings in the template,
+// and converts the maps to `map[string]any`
+func replaceCITemplateTokens(context schema.Context, stack string, value any) any {
+	switch v := value.(type) {
+	case string:
+		return strings.Replace(cfg.ReplaceContextTokens(context, v), "{stack}", stack, -1)
+	case map[string]any:
+		result := make(map[string]any, len(v))
+		for k, item := range v {
+			result[k] = replaceCITemplateTokens(context, stack, item)
+		}
+		return result
+	case map[any]any:
+		result := make(map[string]any, len(v))
+		for k, item := range v {
+			result[fmt.Sprintf("%v", k)] = replaceCITemplateTokens(context, stack, item)
+		}
+		return result
+	case []any:
+		result := make([]any, len(v))
+		for i, item := range v {
+			result[i] = replaceCITemplateTokens(context, stack, item)
+		}
+		return result
+	case []string:
+		result := make([]any, len(v))
+		for i, item := range v {
+			result[i] = replaceCITemplateTokens(context, stack, item)
+		}
+		return result
+	case nil:
+		return map[string]any{}
+	default:
+		return v
+	}
+}
+
+// ciJobDependencies returns the names of the jobs of the dependencies that are in the pipeline
+func ciJobDependencies(job *ciJob, jobNames map[string]string) []string {
+	var result []string
+	for _, dependsOn := range job.dependsOn {
+		if name, ok := jobNames[dependsOn]; ok && name != job.name {
+			result = append(result, name)
+		}
+	}
+	return result
+}
+
+// appendCIJobList appends the values to the list in the job section (e.g. `needs` or `depends_on`), keeping the values from the template
+func appendCIJobList(job map[string]any, key string, values ...string) {
+	var list []any
+	switch existing := job[key].(type) {
+	case []any:
+		list = existing
+	case string:
+		list = []any{existing}
+	}
+	for _, v := range values {
+		list = append(list, v)
+	}
+	job[key] = list
+}
+
+// setCIJobEnv adds the `ATMOS_COMPONENT` and `ATMOS_STACK` variables to the job env section, unless the template sets them
+func setCIJobEnv(job map[string]any, key string, component string, stack string) {
+	env, ok := job[key].(map[string]any)
+	if !ok {
+		env = map[string]any{}
+	}
+	if _, ok := env["ATMOS_COMPONENT"]; !ok {
+		env["ATMOS_COMPONENT"] = component
+	}
+	if _, ok := env["ATMOS_STACK"]; !ok {
+		env["ATMOS_STACK"] = stack
+	}
+	job[key] = env
+}
+
+// buildGitLabPipeline builds the GitLab CI child pipeline with the `plan-<name>` and `apply-<name>` jobs
+func buildGitLabPipeline(pipelineTemplate map[string]any, jobs []*ciJob, jobNames map[string]string) map[string]any {
+	pipeline := map[string]any{}
+	for k, v := range pipelineTemplate {
+		pipeline[k] = v
+	}
+
+	if _, ok := pipeline["stages"]; !ok {
+		pipeline["stages"] = []string{"plan", "apply"}
+	}
+
+	// GitLab doesn't allow a pipeline without jobs
+	if len(jobs) == 0 {
+		pipeline["no-changes"] = map[string]any{
+			"stage":  "plan",
+			"script": []string{"echo 'No terraform components to plan'"},
+		}
+		return pipeline
+	}
+
+	for _, job := range jobs {
+		planJob := job.plan
+		if _, ok := planJob["stage"]; !ok {
+			planJob["stage"] = "plan"
+		}
+		setCIJobEnv(planJob, "variables", job.component, job.stack)
+		pipeline["plan-"+job.name] = planJob
+
+		applyJob := job.apply
+		if _, ok := applyJob["stage"]; !ok {
+			applyJob["stage"] = "apply"
+		}
+		if _, ok := applyJob["when"]; !ok && job.manual {
+			applyJob["when"] = "manual"
+		}
+		setCIJobEnv(applyJob, "variables", job.component, job.stack)
+
+		needs := []string{"plan-" + job.name}
+		for _, name := range ciJobDependencies(job, jobNames) {
+			needs = append(needs, "apply-"+name)
+		}
+		appendCIJobList(applyJob, "needs", needs...)
+
+		pipeline["apply-"+job.name] = applyJob
+	}
+
+	return pipeline
+}
+
+// buildBuildkitePipeline builds the Buildkite pipeline with the `plan-<name>` and `apply-<name>` steps.
+// If the apply is manual, a `block` step is added between the plan and apply steps
+func buildBuildkitePipeline(pipelineTemplate map[string]any, jobs []*ciJob, jobNames map[string]string) map[string]any {
+	pipeline := map[string]any{}
+	for k, v := range pipelineTemplate {
+		pipeline[k] = v
+	}
+
+	steps := []any{}
+	if templateSteps, ok := pipeline["steps"].([]any); ok {
+		steps = append(steps, templateSteps...)
+	}
+
+	for _, job := range jobs {
+		planStep := job.plan
+		planStep["key"] = "plan-" + job.name
+		if _, ok := planStep["label"]; !ok {
+			planStep["label"] = "Plan " + job.name
+		}
+		setCIJobEnv(planStep, "env", job.component, job.stack)
+		steps = append(steps, planStep)
+
+		applyDependsOn := []string{"plan-" + job.name}
+
+		if job.manual {
+			steps = append(steps, map[string]any{
+				"block":      "Apply " + job.name,
+				"key":        "approve-" + job.name,
+				"depends_on": []string{"plan-" + job.name},
+			})
+			applyDependsOn = []string{"approve-" + job.name}
+		}
+
+		for _, name := range ciJobDependencies(job, jobNames) {
+			applyDependsOn = append(applyDependsOn, "apply-"+name)
+		}
+
+		applyStep := job.apply
+		applyStep["key"] = "apply-" + job.name
+		if _, ok := applyStep["label"]; !ok {
+			applyStep["label"] = "Apply " + job.name
+		}
+		setCIJobEnv(applyStep, "env", job.component, job.stack)
+		appendCIJobList(applyStep, "depends_on", applyDependsOn...)
+		steps = append(steps, applyStep)
+	}
+
+	pipeline["steps"] = steps
+
+	return pipeline
+}
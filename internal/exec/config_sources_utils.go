@@ -2,9 +2,16 @@ package exec
 
 import (
 	"fmt"
+	"os"
+	"path"
 	"reflect"
+	"strings"
 
+	yamlv3 "gopkg.in/yaml.v3"
+
+	cfg "github.com/cloudposse/atmos/pkg/config"
 	"github.com/cloudposse/atmos/pkg/schema"
+	u "github.com/cloudposse/atmos/pkg/utils"
 )
 
 // ProcessConfigSources processes the sources (files) for all sections for a component in a stack
@@ -66,6 +73,19 @@ func ProcessConfigSources(
 		backend[name] = obj
 	}
 
+	// `metadata` section
+	metadata := map[string]schema.ConfigSourcesItem{}
+	result["metadata"] = metadata
+
+	for k, v := range configAndStacksInfo.ComponentMetadataSection {
+		name := k.(string)
+		obj := schema.ConfigSourcesItem{}
+		obj.Name = name
+		obj.FinalValue = v
+		obj.StackDependencies = processSectionValueInStacks(configAndStacksInfo, rawStackConfigs, "metadata", "", name)
+		metadata[name] = obj
+	}
+
 	return result, nil
 }
 
@@ -310,6 +330,7 @@ func processComponentSectionValueInStack(
 		StackFileSection: stackFileSection,
 		VariableValue:    rawStackVarVal,
 		DependencyType:   "inline",
+		Component:        component,
 	}
 
 	appendSectionValue(result, val)
@@ -560,6 +581,7 @@ func processComponentSectionValueInStackImports(
 			StackFileSection: stackFileSection,
 			VariableValue:    rawStackVarVal,
 			DependencyType:   "import",
+			Component:        component,
 		}
 
 		appendSectionValue(result, val)
@@ -745,3 +767,99 @@ func appendSectionValue(result *schema.ConfigSourcesStackDependencies, value sch
 	}
 	*result = append(*result, value)
 }
+
+// ProcessConfigProvenance finds the line numbers of all the stack dependencies in the sources
+// and returns the provenance of the final values in the `vars`, `settings`, `env`, `backend` and `metadata` sections,
+// which is the component in the inheritance chain and the stack manifest file and line where the final value was set
+func ProcessConfigProvenance(
+	cliConfig schema.CliConfiguration,
+	sources schema.ConfigSources,
+) (schema.ConfigProvenance, error) {
+	result := schema.ConfigProvenance{}
+
+	// Parsed stack manifests (`nil` if the manifest can't be parsed as YAML, e.g. it's a Go template)
+	stackManifestNodes := map[string]*yamlv3.Node{}
+
+	for section, items := range sources {
+		provenanceSection := map[string]schema.ConfigProvenanceItem{}
+		result[section] = provenanceSection
+
+		for name, item := range items {
+			for i, dep := range item.StackDependencies {
+				line, err := findConfigSourceLine(cliConfig, stackManifestNodes, dep, name)
+				if err != nil {
+					return nil, err
+				}
+				item.StackDependencies[i].Line = line
+			}
+
+			// The stack dependencies are sorted from the highest to the lowest priority, the first one is where the final value was set
+			if len(item.StackDependencies) == 0 {
+				continue
+			}
+
+			dep := item.StackDependencies[0]
+
+			provenanceSection[name] = schema.ConfigProvenanceItem{
+				FinalValue:       item.FinalValue,
+				Component:        dep.Component,
+				StackFile:        dep.StackFile,
+				StackFileSection: dep.StackFileSection,
+				Line:             dep.Line,
+				DependencyType:   dep.DependencyType,
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// findConfigSourceLine returns the line in the stack manifest where the value from the stack dependency is defined.
+// It returns `0` if the stack manifest can't be parsed (e.g. it's a Go template) or the value is not found
+func findConfigSourceLine(
+	cliConfig schema.CliConfiguration,
+	stackManifestNodes map[string]*yamlv3.Node,
+	dep schema.ConfigSourcesStackDependency,
+	name string,
+) (int, error) {
+	var keyPath []string
+
+	// The component section is `components.<component_type>.<section>` (the component name is not included)
+	sectionParts := strings.Split(dep.StackFileSection, ".")
+	if len(sectionParts) > 2 && sectionParts[0] == "components" {
+		keyPath = append(keyPath, sectionParts[:2]...)
+		keyPath = append(keyPath, dep.Component)
+		keyPath = append(keyPath, sectionParts[2:]...)
+	} else {
+		keyPath = append(keyPath, sectionParts...)
+	}
+	keyPath = append(keyPath, name)
+
+	node, ok := stackManifestNodes[dep.StackFile]
+	if !ok {
+		// Stack manifests and imports are identified by the file path without the extension
+		for _, ext := range append(append([]string{}, cfg.StackConfigFileExtensions...), ".tmpl", "") {
+			filePath := path.Join(cliConfig.StacksBaseAbsolutePath, dep.StackFile+ext)
+
+			if !u.FileExists(filePath) {
+				continue
+			}
+
+			content, err := os.ReadFile(filePath)
+			if err != nil {
+				return 0, err
+			}
+
+			node, err = u.ParseYAMLNode(string(content))
+			if err != nil {
+				u.LogTrace(cliConfig, fmt.Sprintf("could not parse the stack manifest '%s' to find the line numbers: %v", filePath, err))
+			}
+			break
+		}
+
+		stackManifestNodes[dep.StackFile] = node
+	}
+
+	line, _ := u.FindYAMLKeyPosition(node, keyPath)
+	return line, nil
+}
@@ -46,8 +46,8 @@ func ExecuteDescribeAffectedCmd(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	if format != "" && format != "yaml" && format != "json" {
-		return fmt.Errorf("invalid '--format' flag '%s'. Valid values are 'json' (default) and 'yaml'", format)
+	if format != "" && format != "yaml" && format != "json" && format != "matrix" && format != "markdown" {
+		return fmt.Errorf("invalid '--format' flag '%s'. Valid values are 'json' (default), 'yaml', 'matrix' and 'markdown'", format)
 	}
 
 	if format == "" {
@@ -79,15 +79,44 @@ func ExecuteDescribeAffectedCmd(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	matrixGroupBy, err := flags.GetString("matrix-group-by")
+	if err != nil {
+		return err
+	}
+
+	githubOutput, err := flags.GetBool("github-output")
+	if err != nil {
+		return err
+	}
+
+	if format != "matrix" && (matrixGroupBy != "" || githubOutput) {
+		return errors.New("the '--matrix-group-by' and '--github-output' flags can only be used with the '--format=matrix' flag")
+	}
+
+	planSummary, err := flags.GetBool("plan-summary")
+	if err != nil {
+		return err
+	}
+
+	markdownTemplate, err := flags.GetString("markdown-template")
+	if err != nil {
+		return err
+	}
+
+	if format != "markdown" && (planSummary || markdownTemplate != "") {
+		return errors.New("the '--plan-summary' and '--markdown-template' flags can only be used with the '--format=markdown' flag")
+	}
+
 	if repoPath != "" && (ref != "" || sha != "" || sshKeyPath != "" || sshKeyPassword != "") {
 		return errors.New("if the '--repo-path' flag is specified, the '--ref', '--sha', '--ssh-key' and '--ssh-key-password' flags can't be used")
 	}
 
 	var affected []schema.Affected
+	var stacks map[string]any
 	if repoPath == "" {
-		affected, err = ExecuteDescribeAffectedWithTargetRepoClone(cliConfig, ref, sha, sshKeyPath, sshKeyPassword, verbose, includeSpaceliftAdminStacks)
+		affected, stacks, err = executeDescribeAffectedWithTargetRepoClone(cliConfig, ref, sha, sshKeyPath, sshKeyPassword, verbose, includeSpaceliftAdminStacks)
 	} else {
-		affected, err = ExecuteDescribeAffectedWithTargetRepoPath(cliConfig, repoPath, verbose, includeSpaceliftAdminStacks)
+		affected, stacks, err = executeDescribeAffectedWithTargetRepoPath(cliConfig, repoPath, verbose, includeSpaceliftAdminStacks)
 	}
 
 	if err != nil {
@@ -96,6 +125,14 @@ func ExecuteDescribeAffectedCmd(cmd *cobra.Command, args []string) error {
 
 	u.LogTrace(cliConfig, fmt.Sprintf("\nAffected components and stacks: \n"))
 
+	if format == "matrix" {
+		return PrintOrWriteAffectedMatrix(cliConfig, affected, matrixGroupBy, file, githubOutput)
+	}
+
+	if format == "markdown" {
+		return printOrWriteAffectedMarkdown(cliConfig, affected, stacks, planSummary, markdownTemplate, file)
+	}
+
 	err = printOrWriteToFile(format, file, affected)
 	if err != nil {
 		return err
@@ -0,0 +1,294 @@
+package exec
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/cloudposse/atmos/pkg/schema"
+	u "github.com/cloudposse/atmos/pkg/utils"
+)
+
+// defaultAffectedMarkdownTemplate is the Go template used by `atmos describe affected --format markdown`
+// if the `--markdown-template` flag is not specified
+//
+//go:embed templates/describe-affected.md.tmpl
+var defaultAffectedMarkdownTemplate string
+
+// terraformPlanJson is the part of the `terraform show -json <planfile>` output used to summarize the plan
+type terraformPlanJson struct {
+	ResourceChanges []struct {
+		Address string `json:"address"`
+		Change  struct {
+			Actions []string `json:"actions"`
+		} `json:"change"`
+	} `json:"resource_changes"`
+}
+
+// BuildAffectedMarkdown groups the affected components by stack (the stacks and the components in each stack are sorted by name).
+// `planSummaries` are the Terraform plan summaries keyed by `<component_type>/<stack>/<component>`, and `planErrors` are the errors reading them.
+// If `planSummary` is `true`, the template renders the add/change/destroy counts
+func BuildAffectedMarkdown(
+	affected []schema.Affected,
+	planSummary bool,
+	planSummaries map[string]*schema.TerraformPlanSummary,
+	planErrors map[string]string,
+) schema.AffectedMarkdown {
+	result := schema.AffectedMarkdown{
+		Stacks:      []schema.AffectedMarkdownStack{},
+		PlanSummary: planSummary,
+	}
+
+	componentsByStack := map[string][]schema.AffectedMarkdownComponent{}
+
+	for _, a := range affected {
+		key := affectedMatrixItemKey(a.ComponentType, a.Stack, a.Component)
+
+		componentsByStack[a.Stack] = append(componentsByStack[a.Stack], schema.AffectedMarkdownComponent{
+			Component:       a.Component,
+			ComponentType:   a.ComponentType,
+			ComponentPath:   a.ComponentPath,
+			Stack:           a.Stack,
+			SpaceliftStack:  a.SpaceliftStack,
+			AtlantisProject: a.AtlantisProject,
+			Affected:        a.Affected,
+			Plan:            planSummaries[key],
+			PlanError:       planErrors[key],
+		})
+		result.Total++
+	}
+
+	var stacks []string
+	for stack := range componentsByStack {
+		stacks = append(stacks, stack)
+	}
+	sort.Strings(stacks)
+
+	for _, stack := range stacks {
+		components := componentsByStack[stack]
+
+		sort.SliceStable(components, func(i, j int) bool {
+			if components[i].Component == components[j].Component {
+				return components[i].ComponentType < components[j].ComponentType
+			}
+			return components[i].Component < components[j].Component
+		})
+
+		result.Stacks = append(result.Stacks, schema.AffectedMarkdownStack{
+			Stack:      stack,
+			Components: components,
+		})
+	}
+
+	return result
+}
+
+// RenderAffectedMarkdown renders the affected components as markdown using the Go template (with the Sprig functions).
+// If `tmpl` is empty, the default template is used
+func RenderAffectedMarkdown(data schema.AffectedMarkdown, tmpl string) (string, error) {
+	if tmpl == "" {
+		tmpl = defaultAffectedMarkdownTemplate
+	}
+
+	return u.ProcessTmpl("describe-affected-markdown", tmpl, data, false)
+}
+
+// TerraformPlanSummaryFromJson summarizes the `terraform show -json <planfile>` output.
+// The replaced resources are counted as both added and destroyed (the same way `terraform plan` does), and the data sources
+// and the resources without changes are skipped
+func TerraformPlanSummaryFromJson(planJson []byte) (*schema.TerraformPlanSummary, error) {
+	var plan terraformPlanJson
+	if err := json.Unmarshal(planJson, &plan); err != nil {
+		return nil, err
+	}
+
+	summary := schema.TerraformPlanSummary{
+		Resources: []schema.TerraformPlanResourceChange{},
+	}
+
+	for _, rc := range plan.ResourceChanges {
+		var create, update, del bool
+		for _, action := range rc.Change.Actions {
+			switch action {
+			case "create":
+				create = true
+			case "update":
+				update = true
+			case "delete":
+				del = true
+			}
+		}
+
+		var action string
+		switch {
+		case create && del:
+			action = "replace"
+			summary.Add++
+			summary.Destroy++
+		case create:
+			action = "create"
+			summary.Add++
+		case update:
+			action = "update"
+			summary.Change++
+		case del:
+			action = "delete"
+			summary.Destroy++
+		default:
+			continue
+		}
+
+		summary.Resources = append(summary.Resources, schema.TerraformPlanResourceChange{
+			Address: rc.Address,
+			Action:  action,
+		})
+	}
+
+	return &summary, nil
+}
+
+// readAffectedPlanSummary executes `terraform show -json` on the planfile that `atmos terraform plan` saved
+// for the terraform component in the stack, and summarizes the plan.
+// The planfile, the working dir, the command, the workspace and the ENV vars are taken from the component section in the described `stacks`
+func readAffectedPlanSummary(
+	cliConfig schema.CliConfiguration,
+	stacks map[string]any,
+	affected schema.Affected,
+) (*schema.TerraformPlanSummary, error) {
+	componentSection, ok := findAffectedComponentSection(stacks, affected)
+	if !ok {
+		return nil, fmt.Errorf("the component '%s' is not found in the stack '%s'", affected.Component, affected.Stack)
+	}
+
+	// `describe stacks` sets the `component` attribute to the Atmos component name if the component does not inherit from a base component
+	baseComponentPath, ok := componentSection["component"].(string)
+	if !ok || baseComponentPath == "" {
+		baseComponentPath = affected.Component
+	}
+
+	// The described stack name is the context prefix calculated from the stack name pattern
+	info := schema.ConfigAndStacksInfo{
+		ContextPrefix:  affected.Stack,
+		Component:      path.Base(affected.Component),
+		FinalComponent: path.Base(baseComponentPath),
+	}
+
+	if strings.Contains(baseComponentPath, "/") {
+		info.ComponentFolderPrefix = path.Dir(baseComponentPath)
+		info.ComponentFolderPrefixReplaced = strings.Replace(info.ComponentFolderPrefix, "/", "-", -1)
+	}
+
+	workingDir := constructTerraformComponentWorkingDir(cliConfig, info)
+	planFile := constructTerraformComponentPlanfileName(info)
+
+	if !u.FileExists(path.Join(workingDir, planFile)) {
+		return nil, fmt.Errorf("the planfile '%s' does not exist. Execute 'atmos terraform plan %s -s %s' to generate it",
+			planFile, affected.Component, affected.Stack)
+	}
+
+	command, ok := componentSection["command"].(string)
+	if !ok || command == "" {
+		command = "terraform"
+	}
+
+	// Remove the ENV vars that are set to `null` in the `env` section (the same way `atmos terraform` does)
+	envSection := map[any]any{}
+	if i, ok := componentSection["env"].(map[any]any); ok {
+		for k, v := range i {
+			if v != nil {
+				envSection[k] = v
+			}
+		}
+	}
+
+	env := append(u.ConvertEnvVars(envSection), "TF_IN_AUTOMATION=true")
+	if workspace, ok := componentSection["workspace"].(string); ok && workspace != "" {
+		env = append(env, fmt.Sprintf("TF_WORKSPACE=%s", workspace))
+	}
+
+	planJson, err := ExecuteShellCommandAndReturnOutput(cliConfig, command, []string{"show", "-json", planFile}, workingDir, env)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the planfile '%s': %w", planFile, err)
+	}
+
+	return TerraformPlanSummaryFromJson([]byte(planJson))
+}
+
+// findAffectedComponentSection returns the section of the affected terraform component in the described `stacks`
+func findAffectedComponentSection(stacks map[string]any, affected schema.Affected) (map[string]any, bool) {
+	stackSection, ok := stacks[affected.Stack].(map[string]any)
+	if !ok {
+		return nil, false
+	}
+
+	componentsSection, ok := stackSection["components"].(map[string]any)
+	if !ok {
+		return nil, false
+	}
+
+	terraformSection, ok := componentsSection["terraform"].(map[string]any)
+	if !ok {
+		return nil, false
+	}
+
+	componentSection, ok := terraformSection[affected.Component].(map[string]any)
+	return componentSection, ok
+}
+
+// printOrWriteAffectedMarkdown renders the affected components as markdown, and prints it or writes it to the file.
+// If `planSummary` is `true`, the saved planfiles of the affected terraform components (found in the described `stacks`) are summarized
+func printOrWriteAffectedMarkdown(
+	cliConfig schema.CliConfiguration,
+	affected []schema.Affected,
+	stacks map[string]any,
+	planSummary bool,
+	markdownTemplateFile string,
+	file string,
+) error {
+	var tmpl string
+	if markdownTemplateFile != "" {
+		b, err := os.ReadFile(markdownTemplateFile)
+		if err != nil {
+			return fmt.Errorf("failed to read the markdown template '%s': %w", markdownTemplateFile, err)
+		}
+		tmpl = string(b)
+	}
+
+	planSummaries := map[string]*schema.TerraformPlanSummary{}
+	planErrors := map[string]string{}
+
+	if planSummary {
+		for _, a := range affected {
+			if a.ComponentType != "terraform" {
+				continue
+			}
+
+			key := affectedMatrixItemKey(a.ComponentType, a.Stack, a.Component)
+
+			summary, err := readAffectedPlanSummary(cliConfig, stacks, a)
+			if err != nil {
+				// Don't fail the whole comment if one plan can't be read, show the error in the component section instead
+				u.LogWarning(cliConfig, err.Error())
+				planErrors[key] = err.Error()
+				continue
+			}
+			planSummaries[key] = summary
+		}
+	}
+
+	markdown, err := RenderAffectedMarkdown(BuildAffectedMarkdown(affected, planSummary, planSummaries, planErrors), tmpl)
+	if err != nil {
+		return err
+	}
+
+	if file == "" {
+		u.PrintMessage(markdown)
+		return nil
+	}
+
+	return os.WriteFile(file, []byte(markdown+"\n"), 0644)
+}
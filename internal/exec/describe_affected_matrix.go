@@ -0,0 +1,264 @@
+package exec
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+
+	cfg "github.com/cloudposse/atmos/pkg/config"
+	"github.com/cloudposse/atmos/pkg/schema"
+	u "github.com/cloudposse/atmos/pkg/utils"
+)
+
+// AffectedMatrixVersion is the version of the `atmos describe affected --format matrix` output schema.
+// Increment it on any breaking change of the schema (renamed or removed fields, changed structure)
+const AffectedMatrixVersion = 1
+
+// affectedMatrixGroupByDependencyLevel groups the affected components by the dependency level
+const affectedMatrixGroupByDependencyLevel = "dependency-level"
+
+// BuildAffectedMatrix converts the affected components into the GitHub Actions matrix items, and calculates the dependency level
+// of each item from the `settings.depends_on` sections: the components that don't depend on other affected components are at level `0`,
+// and each component is at the level after the levels of all the affected components it depends on
+func BuildAffectedMatrix(cliConfig schema.CliConfiguration, affected []schema.Affected) ([]schema.AffectedMatrixItem, error) {
+	var items []schema.AffectedMatrixItem
+	itemIndexes := map[string]int{}
+
+	for _, a := range affected {
+		key := affectedMatrixItemKey(a.ComponentType, a.Stack, a.Component)
+		if _, ok := itemIndexes[key]; ok {
+			continue
+		}
+
+		itemIndexes[key] = len(items)
+		items = append(items, schema.AffectedMatrixItem{
+			Component:       a.Component,
+			ComponentType:   a.ComponentType,
+			ComponentPath:   a.ComponentPath,
+			Namespace:       a.Namespace,
+			Tenant:          a.Tenant,
+			Environment:     a.Environment,
+			Stage:           a.Stage,
+			Stack:           a.Stack,
+			StackSlug:       a.StackSlug,
+			SpaceliftStack:  a.SpaceliftStack,
+			AtlantisProject: a.AtlantisProject,
+			Affected:        a.Affected,
+		})
+	}
+
+	if len(items) == 0 {
+		return []schema.AffectedMatrixItem{}, nil
+	}
+
+	stacks, err := ExecuteDescribeStacks(cliConfig, "", nil, nil, []string{"settings"}, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// The dependencies of each item on the other affected items
+	dependencies := make([][]int, len(items))
+
+	for i, item := range items {
+		var settingsSection map[any]any
+		if stackSection, ok := stacks[item.Stack].(map[string]any); ok {
+			if componentsSection, ok := stackSection["components"].(map[string]any); ok {
+				if componentTypeSection, ok := componentsSection[item.ComponentType].(map[string]any); ok {
+					if componentSection, ok := componentTypeSection[item.Component].(map[string]any); ok {
+						settingsSection, _ = componentSection["settings"].(map[any]any)
+					}
+				}
+			}
+		}
+
+		var settings schema.Settings
+		if err = mapstructure.Decode(settingsSection, &settings); err != nil {
+			return nil, err
+		}
+
+		for _, dependsOn := range settings.DependsOn {
+			if dependsOn.Component == "" {
+				continue
+			}
+
+			dependsOnStack := item.Stack
+
+			if cliConfig.Stacks.NamePattern != "" {
+				dependsOn = dependsOnContext(dependsOn, schema.Context{
+					Namespace:   item.Namespace,
+					Tenant:      item.Tenant,
+					Environment: item.Environment,
+					Stage:       item.Stage,
+				})
+
+				dependsOnStack, err = cfg.GetContextPrefix(item.Stack, dependsOn, cliConfig.Stacks.NamePattern, item.Stack)
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			// Only the dependencies that are also affected change the order
+			if j, ok := itemIndexes[affectedMatrixItemKey(item.ComponentType, dependsOnStack, dependsOn.Component)]; ok && j != i {
+				dependencies[i] = append(dependencies[i], j)
+			}
+		}
+	}
+
+	// Topological sort of the items
+	const (
+		notVisited = iota
+		visiting
+		visited
+	)
+
+	state := make([]int, len(items))
+
+	var visit func(i int, path []string) error
+	visit = func(i int, path []string) error {
+		path = append(path, fmt.Sprintf("'%s' in the stack '%s'", items[i].Component, items[i].Stack))
+
+		switch state[i] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf("circular dependency between the affected components: %s", strings.Join(path, " -> "))
+		}
+
+		state[i] = visiting
+		level := 0
+
+		for _, j := range dependencies[i] {
+			if err := visit(j, path); err != nil {
+				return err
+			}
+			if items[j].DependencyLevel+1 > level {
+				level = items[j].DependencyLevel + 1
+			}
+		}
+
+		items[i].DependencyLevel = level
+		state[i] = visited
+
+		return nil
+	}
+
+	for i := range items {
+		if err = visit(i, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].DependencyLevel < items[j].DependencyLevel
+	})
+
+	return items, nil
+}
+
+// GroupAffectedMatrixByDependencyLevel splits the matrix items into one matrix per dependency level, so each level can be a separate job
+func GroupAffectedMatrixByDependencyLevel(items []schema.AffectedMatrixItem) schema.AffectedMatrixLevels {
+	result := schema.AffectedMatrixLevels{
+		Version: AffectedMatrixVersion,
+		Levels:  []schema.AffectedMatrix{},
+	}
+
+	for _, item := range items {
+		for len(result.Levels) <= item.DependencyLevel {
+			result.Levels = append(result.Levels, schema.AffectedMatrix{Include: []schema.AffectedMatrixItem{}})
+		}
+		result.Levels[item.DependencyLevel].Include = append(result.Levels[item.DependencyLevel].Include, item)
+	}
+
+	return result
+}
+
+// affectedMatrixItemKey returns the key of the component in the stack
+func affectedMatrixItemKey(componentType string, stack string, component string) string {
+	return componentType + "/" + stack + "/" + component
+}
+
+// PrintOrWriteAffectedMatrix prints the matrix or writes it to the file, and if `githubOutput` is `true`, writes the outputs
+// to the file pointed to by the `GITHUB_OUTPUT` ENV var
+func PrintOrWriteAffectedMatrix(
+	cliConfig schema.CliConfiguration,
+	affected []schema.Affected,
+	groupBy string,
+	file string,
+	githubOutput bool,
+) error {
+	if groupBy != "" && groupBy != affectedMatrixGroupByDependencyLevel {
+		return fmt.Errorf("invalid '--matrix-group-by' flag '%s'. Valid values are '%s'", groupBy, affectedMatrixGroupByDependencyLevel)
+	}
+
+	items, err := BuildAffectedMatrix(cliConfig, affected)
+	if err != nil {
+		return err
+	}
+
+	outputs := map[string]any{
+		"matrix_version": AffectedMatrixVersion,
+		"has_affected":   len(items) > 0,
+	}
+
+	var result any
+
+	if groupBy == affectedMatrixGroupByDependencyLevel {
+		levels := GroupAffectedMatrixByDependencyLevel(items)
+		result = levels
+
+		outputs["matrix_levels"] = len(levels.Levels)
+		for i, level := range levels.Levels {
+			outputs["matrix_level_"+strconv.Itoa(i)] = level
+		}
+	} else {
+		result = schema.AffectedMatrix{Version: AffectedMatrixVersion, Include: items}
+
+		// The `matrix` output is used as the GitHub Actions matrix, where any key other than `include` and `exclude` is a matrix dimension,
+		// so the version is written to the `matrix_version` output instead
+		outputs["matrix"] = schema.AffectedMatrix{Include: items}
+	}
+
+	if err = printOrWriteToFile("json", file, result); err != nil {
+		return err
+	}
+
+	if !githubOutput {
+		return nil
+	}
+
+	githubOutputFile := os.Getenv("GITHUB_OUTPUT")
+	if githubOutputFile == "" {
+		return fmt.Errorf("the '--github-output' flag is specified, but the 'GITHUB_OUTPUT' ENV var is not set")
+	}
+
+	return WriteGithubOutputs(cliConfig, githubOutputFile, outputs)
+}
+
+// WriteGithubOutputs appends the outputs to the GitHub Actions output file in the `name=value` format.
+// The values that are not strings are written as compact JSON on a single line
+func WriteGithubOutputs(cliConfig schema.CliConfiguration, githubOutputFile string, outputs map[string]any) error {
+	var sb strings.Builder
+
+	for _, name := range u.StringKeysFromMap(outputs) {
+		value, err := u.ConvertToJSONFast(outputs[name])
+		if err != nil {
+			return err
+		}
+		sb.WriteString(name + "=" + value + "\n")
+	}
+
+	u.LogDebug(cliConfig, fmt.Sprintf("Writing the outputs to the GitHub Actions output file '%s'", githubOutputFile))
+
+	f, err := os.OpenFile(githubOutputFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = f.WriteString(sb.String())
+	return err
+}
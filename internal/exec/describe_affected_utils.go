@@ -6,6 +6,7 @@ import (
 	"path"
 	"path/filepath"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -39,23 +40,39 @@ func ExecuteDescribeAffectedWithTargetRepoClone(
 	includeSpaceliftAdminStacks bool,
 ) ([]schema.Affected, error) {
 
+	affected, _, err := executeDescribeAffectedWithTargetRepoClone(cliConfig, ref, sha, sshKeyPath, sshKeyPassword, verbose, includeSpaceliftAdminStacks)
+	return affected, err
+}
+
+// executeDescribeAffectedWithTargetRepoClone clones the remote repo using `ref` or `sha`, and returns the affected Atmos components and stacks
+// and the described stacks in the current working repo
+func executeDescribeAffectedWithTargetRepoClone(
+	cliConfig schema.CliConfiguration,
+	ref string,
+	sha string,
+	sshKeyPath string,
+	sshKeyPassword string,
+	verbose bool,
+	includeSpaceliftAdminStacks bool,
+) ([]schema.Affected, map[string]any, error) {
+
 	localRepo, err := git.PlainOpenWithOptions(".", &git.PlainOpenOptions{
 		DetectDotGit:          true,
 		EnableDotGitCommonDir: false,
 	})
 	if err != nil {
-		return nil, errors.Wrapf(err, "%v", localRepoIsNotGitRepoError)
+		return nil, nil, errors.Wrapf(err, "%v", localRepoIsNotGitRepoError)
 	}
 
 	// Get the Git config of the local repo
 	localRepoConfig, err := localRepo.Config()
 	if err != nil {
-		return nil, errors.Wrapf(err, "%v", localRepoIsNotGitRepoError)
+		return nil, nil, errors.Wrapf(err, "%v", localRepoIsNotGitRepoError)
 	}
 
 	localRepoWorktree, err := localRepo.Worktree()
 	if err != nil {
-		return nil, errors.Wrapf(err, "%v", localRepoIsNotGitRepoError)
+		return nil, nil, errors.Wrapf(err, "%v", localRepoIsNotGitRepoError)
 	}
 
 	localRepoPath := localRepoWorktree.Filesystem.Root()
@@ -67,18 +84,18 @@ func ExecuteDescribeAffectedWithTargetRepoClone(
 	}
 
 	if len(keys) == 0 {
-		return nil, localRepoIsNotGitRepoError
+		return nil, nil, localRepoIsNotGitRepoError
 	}
 
 	// Get the origin URL of the current remoteRepo
 	remoteUrls := localRepoConfig.Remotes[keys[0]].URLs
 	if len(remoteUrls) == 0 {
-		return nil, localRepoIsNotGitRepoError
+		return nil, nil, localRepoIsNotGitRepoError
 	}
 
 	repoUrl := remoteUrls[0]
 	if repoUrl == "" {
-		return nil, localRepoIsNotGitRepoError
+		return nil, nil, localRepoIsNotGitRepoError
 	}
 
 	// Clone the remote repo
@@ -93,7 +110,7 @@ func ExecuteDescribeAffectedWithTargetRepoClone(
 	// Create a temp dir to clone the remote repo to
 	tempDir, err := os.MkdirTemp("", strconv.FormatInt(time.Now().Unix(), 10))
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	defer removeTempDir(cliConfig, tempDir)
@@ -124,12 +141,12 @@ func ExecuteDescribeAffectedWithTargetRepoClone(
 	if sshKeyPath != "" {
 		sshKeyContent, err := os.ReadFile(sshKeyPath)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		sshPublicKey, err := ssh.NewPublicKeys("git", sshKeyContent, sshKeyPassword)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		// Use the SSH key to clone the repo
@@ -142,12 +159,12 @@ func ExecuteDescribeAffectedWithTargetRepoClone(
 
 	remoteRepo, err := git.PlainClone(tempDir, false, &cloneOptions)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	remoteRepoHead, err := remoteRepo.Head()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	if ref != "" {
@@ -162,7 +179,7 @@ func ExecuteDescribeAffectedWithTargetRepoClone(
 
 		w, err := remoteRepo.Worktree()
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		checkoutOptions := git.CheckoutOptions{
@@ -174,18 +191,13 @@ func ExecuteDescribeAffectedWithTargetRepoClone(
 
 		err = w.Checkout(&checkoutOptions)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		u.LogTrace(cliConfig, fmt.Sprintf("\nChecked out commit SHA '%s'\n", sha))
 	}
 
-	affected, err := executeDescribeAffected(cliConfig, localRepoPath, tempDir, localRepo, remoteRepo, verbose, includeSpaceliftAdminStacks)
-	if err != nil {
-		return nil, err
-	}
-
-	return affected, nil
+	return executeDescribeAffected(cliConfig, localRepoPath, tempDir, localRepo, remoteRepo, verbose, includeSpaceliftAdminStacks)
 }
 
 // ExecuteDescribeAffectedWithTargetRepoPath uses `repo-path` to access the target repo, and processes stack configs
@@ -197,23 +209,36 @@ func ExecuteDescribeAffectedWithTargetRepoPath(
 	includeSpaceliftAdminStacks bool,
 ) ([]schema.Affected, error) {
 
+	affected, _, err := executeDescribeAffectedWithTargetRepoPath(cliConfig, repoPath, verbose, includeSpaceliftAdminStacks)
+	return affected, err
+}
+
+// executeDescribeAffectedWithTargetRepoPath uses `repo-path` to access the target repo, and returns the affected Atmos components and stacks
+// and the described stacks in the current working repo
+func executeDescribeAffectedWithTargetRepoPath(
+	cliConfig schema.CliConfiguration,
+	repoPath string,
+	verbose bool,
+	includeSpaceliftAdminStacks bool,
+) ([]schema.Affected, map[string]any, error) {
+
 	localRepo, err := git.PlainOpenWithOptions(".", &git.PlainOpenOptions{
 		DetectDotGit:          true,
 		EnableDotGitCommonDir: false,
 	})
 	if err != nil {
-		return nil, errors.Wrapf(err, "%v", localRepoIsNotGitRepoError)
+		return nil, nil, errors.Wrapf(err, "%v", localRepoIsNotGitRepoError)
 	}
 
 	// Check the Git config of the local repo
 	_, err = localRepo.Config()
 	if err != nil {
-		return nil, errors.Wrapf(err, "%v", localRepoIsNotGitRepoError)
+		return nil, nil, errors.Wrapf(err, "%v", localRepoIsNotGitRepoError)
 	}
 
 	localRepoWorktree, err := localRepo.Worktree()
 	if err != nil {
-		return nil, errors.Wrapf(err, "%v", localRepoIsNotGitRepoError)
+		return nil, nil, errors.Wrapf(err, "%v", localRepoIsNotGitRepoError)
 	}
 
 	localRepoPath := localRepoWorktree.Filesystem.Root()
@@ -223,21 +248,16 @@ func ExecuteDescribeAffectedWithTargetRepoPath(
 		EnableDotGitCommonDir: false,
 	})
 	if err != nil {
-		return nil, errors.Wrapf(err, "%v", remoteRepoIsNotGitRepoError)
+		return nil, nil, errors.Wrapf(err, "%v", remoteRepoIsNotGitRepoError)
 	}
 
 	// Check the Git config of the remote target repo
 	_, err = remoteRepo.Config()
 	if err != nil {
-		return nil, errors.Wrapf(err, "%v", remoteRepoIsNotGitRepoError)
+		return nil, nil, errors.Wrapf(err, "%v", remoteRepoIsNotGitRepoError)
 	}
 
-	affected, err := executeDescribeAffected(cliConfig, localRepoPath, repoPath, localRepo, remoteRepo, verbose, includeSpaceliftAdminStacks)
-	if err != nil {
-		return nil, err
-	}
-
-	return affected, nil
+	return executeDescribeAffected(cliConfig, localRepoPath, repoPath, localRepo, remoteRepo, verbose, includeSpaceliftAdminStacks)
 }
 
 func executeDescribeAffected(
@@ -248,7 +268,7 @@ func executeDescribeAffected(
 	remoteRepo *git.Repository,
 	verbose bool,
 	includeSpaceliftAdminStacks bool,
-) ([]schema.Affected, error) {
+) ([]schema.Affected, map[string]any, error) {
 
 	if verbose {
 		cliConfig.Logs.Level = u.LogLevelTrace
@@ -256,12 +276,12 @@ func executeDescribeAffected(
 
 	localRepoHead, err := localRepo.Head()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	remoteRepoHead, err := remoteRepo.Head()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	u.LogTrace(cliConfig, fmt.Sprintf("Current working repo HEAD: %s", localRepoHead))
@@ -269,12 +289,12 @@ func executeDescribeAffected(
 
 	currentStacks, err := ExecuteDescribeStacks(cliConfig, "", nil, nil, nil, false)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	localRepoFileSystemPathAbs, err := filepath.Abs(localRepoFileSystemPath)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	basePath := cliConfig.BasePath
@@ -286,7 +306,7 @@ func executeDescribeAffected(
 	if path.IsAbs(basePath) {
 		basePath, err = filepath.Rel(localRepoFileSystemPathAbs, basePath)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 	}
 
@@ -300,19 +320,19 @@ func executeDescribeAffected(
 		cliConfig.StackConfigFilesRelativePaths,
 	)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	remoteStacks, err := ExecuteDescribeStacks(cliConfig, "", nil, nil, nil, true)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	u.LogTrace(cliConfig, fmt.Sprintf("\nGetting current working repo commit object..."))
 
 	localCommit, err := localRepo.CommitObject(localRepoHead.Hash())
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	u.LogTrace(cliConfig, fmt.Sprintf("Got current working repo commit object"))
@@ -320,7 +340,7 @@ func executeDescribeAffected(
 
 	localTree, err := localCommit.Tree()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	u.LogTrace(cliConfig, fmt.Sprintf("Got current working repo commit tree"))
@@ -328,7 +348,7 @@ func executeDescribeAffected(
 
 	remoteCommit, err := remoteRepo.CommitObject(remoteRepoHead.Hash())
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	u.LogTrace(cliConfig, fmt.Sprintf("Got remote repo commit object"))
@@ -336,7 +356,7 @@ func executeDescribeAffected(
 
 	remoteTree, err := remoteCommit.Tree()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	u.LogTrace(cliConfig, fmt.Sprintf("Got remote repo commit tree"))
@@ -345,7 +365,7 @@ func executeDescribeAffected(
 	// Find a slice of Patch objects with all the changes between the current working and remote trees
 	patch, err := localTree.Patch(remoteTree)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	u.LogTrace(cliConfig, fmt.Sprintf("Found diff between the current working branch and remote target branch"))
@@ -361,10 +381,10 @@ func executeDescribeAffected(
 
 	affected, err := findAffected(currentStacks, remoteStacks, cliConfig, changedFiles, includeSpaceliftAdminStacks)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return affected, nil
+	return affected, currentStacks, nil
 }
 
 // findAffected returns a list of all affected components in all stacks
@@ -394,6 +414,11 @@ func findAffected(
 										continue
 									}
 								}
+								// Skip disabled components (`metadata.enabled` and `metadata.enabled_when` attributes)
+								varsSection, _ := componentSection["vars"].(map[any]any)
+								if !IsComponentEnabled(metadataSection, varsSection) {
+									continue
+								}
 								// Check `metadata` section
 								if !isEqual(remoteStacks, stackName, "terraform", componentName, metadataSection, "metadata") {
 									affected := schema.Affected{
@@ -627,6 +652,11 @@ func findAffected(
 										continue
 									}
 								}
+								// Skip disabled components (`metadata.enabled` and `metadata.enabled_when` attributes)
+								varsSection, _ := componentSection["vars"].(map[any]any)
+								if !IsComponentEnabled(metadataSection, varsSection) {
+									continue
+								}
 								// Check `metadata` section
 								if !isEqual(remoteStacks, stackName, "helmfile", componentName, metadataSection, "metadata") {
 									affected := schema.Affected{
@@ -1043,7 +1073,10 @@ func areTerraformComponentModulesChanged(
 		return false, err
 	}
 
-	terraformConfiguration, _ := tfconfig.LoadModule(componentPathAbs)
+	modulePaths, err := findTerraformComponentLocalModules(componentPathAbs)
+	if err != nil {
+		return false, err
+	}
 
 	for _, changedFile := range changedFiles {
 		changedFileAbs, err := filepath.Abs(changedFile)
@@ -1051,19 +1084,7 @@ func areTerraformComponentModulesChanged(
 			return false, err
 		}
 
-		for _, moduleConfig := range terraformConfiguration.ModuleCalls {
-			// We are processing the local modules only (not from terraform registry), they will have `Version` as an empty string
-			if moduleConfig.Version != "" {
-				continue
-			}
-
-			modulePath := path.Join(path.Dir(moduleConfig.Pos.Filename), moduleConfig.Source)
-
-			modulePathAbs, err := filepath.Abs(modulePath)
-			if err != nil {
-				return false, err
-			}
-
+		for _, modulePathAbs := range modulePaths {
 			modulePathPattern := modulePathAbs + "/**"
 
 			match, err := u.PathMatch(modulePathPattern, changedFileAbs)
@@ -1080,6 +1101,39 @@ func areTerraformComponentModulesChanged(
 	return false, nil
 }
 
+// findTerraformComponentLocalModules returns the absolute paths to the local modules (not from a Terraform registry or a remote source)
+// that the Terraform component in the folder `componentPathAbs` calls
+func findTerraformComponentLocalModules(componentPathAbs string) ([]string, error) {
+	var result []string
+
+	terraformConfiguration, _ := tfconfig.LoadModule(componentPathAbs)
+
+	for _, moduleConfig := range terraformConfiguration.ModuleCalls {
+		// We are processing the local modules only (not from terraform registry), they will have `Version` as an empty string
+		if moduleConfig.Version != "" {
+			continue
+		}
+
+		// Local modules are referenced by the paths starting with `./` or `../`
+		if !strings.HasPrefix(moduleConfig.Source, "./") && !strings.HasPrefix(moduleConfig.Source, "../") {
+			continue
+		}
+
+		modulePath := path.Join(path.Dir(moduleConfig.Pos.Filename), moduleConfig.Source)
+
+		modulePathAbs, err := filepath.Abs(modulePath)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, modulePathAbs)
+	}
+
+	sort.Strings(result)
+
+	return u.UniqueStrings(result), nil
+}
+
 // addAffectedSpaceliftAdminStack adds the affected Spacelift admin stack that manages the affected child stack
 func addAffectedSpaceliftAdminStack(
 	cliConfig schema.CliConfiguration,
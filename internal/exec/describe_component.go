@@ -14,12 +14,17 @@ func ExecuteDescribeComponentCmd(cmd *cobra.Command, args []string) error {
 		return errors.New("invalid arguments. The command requires one argument `component`")
 	}
 
-	_, err := cfg.InitCliConfig(schema.ConfigAndStacksInfo{}, true)
+	flags := cmd.Flags()
+
+	cliConfigFile, err := flags.GetString("config")
 	if err != nil {
 		return err
 	}
 
-	flags := cmd.Flags()
+	_, err = cfg.InitCliConfig(schema.ConfigAndStacksInfo{CliConfigFile: cliConfigFile}, true)
+	if err != nil {
+		return err
+	}
 
 	stack, err := flags.GetString("stack")
 	if err != nil {
@@ -36,9 +41,14 @@ func ExecuteDescribeComponentCmd(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	provenance, err := flags.GetBool("provenance")
+	if err != nil {
+		return err
+	}
+
 	component := args[0]
 
-	componentSection, err := ExecuteDescribeComponent(component, stack)
+	componentSection, err := executeDescribeComponent(component, stack, provenance, cliConfigFile)
 	if err != nil {
 		return err
 	}
@@ -53,9 +63,21 @@ func ExecuteDescribeComponentCmd(cmd *cobra.Command, args []string) error {
 
 // ExecuteDescribeComponent describes component config
 func ExecuteDescribeComponent(component string, stack string) (map[string]any, error) {
+	return executeDescribeComponent(component, stack, false, "")
+}
+
+// ExecuteDescribeComponentWithProvenance describes component config and adds the `provenance` section,
+// which shows the component in the inheritance chain and the stack manifest file and line where each final value is set
+func ExecuteDescribeComponentWithProvenance(component string, stack string) (map[string]any, error) {
+	return executeDescribeComponent(component, stack, true, "")
+}
+
+func executeDescribeComponent(component string, stack string, provenance bool, cliConfigFile string) (map[string]any, error) {
 	var configAndStacksInfo schema.ConfigAndStacksInfo
 	configAndStacksInfo.ComponentFromArg = component
 	configAndStacksInfo.Stack = stack
+	configAndStacksInfo.Provenance = provenance
+	configAndStacksInfo.CliConfigFile = cliConfigFile
 
 	cliConfig, err := cfg.InitCliConfig(configAndStacksInfo, true)
 	if err != nil {
@@ -101,9 +101,24 @@ func ExecuteDescribeStacks(
 	ignoreMissingFiles bool,
 ) (map[string]any, error) {
 
-	stacksMap, _, err := FindStacksMap(cliConfig, ignoreMissingFiles)
+	finalStacksMap, _, err := executeDescribeStacks(cliConfig, filterByStack, components, componentTypes, sections, ignoreMissingFiles)
+	return finalStacksMap, err
+}
+
+// executeDescribeStacks processes stack manifests and returns the final map of stacks and components,
+// and the raw stack configs (used to find the stack manifests where the component sections are defined)
+func executeDescribeStacks(
+	cliConfig schema.CliConfiguration,
+	filterByStack string,
+	components []string,
+	componentTypes []string,
+	sections []string,
+	ignoreMissingFiles bool,
+) (map[string]any, map[string]map[string]any, error) {
+
+	stacksMap, rawStackConfigs, err := FindStacksMap(cliConfig, ignoreMissingFiles)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	finalStacksMap := make(map[string]any)
@@ -123,7 +138,7 @@ func ExecuteDescribeStacks(
 					for componentName, compSection := range terraformSection {
 						componentSection, ok := compSection.(map[string]any)
 						if !ok {
-							return nil, fmt.Errorf("invalid 'components.terraform.%s' section in the file '%s'", componentName, stackFileName)
+							return nil, nil, fmt.Errorf("invalid 'components.terraform.%s' section in the file '%s'", componentName, stackFileName)
 						}
 
 						if comp, ok := componentSection["component"].(string); !ok || comp == "" {
@@ -133,7 +148,7 @@ func ExecuteDescribeStacks(
 						// Find all derived components of the provided components and include them in the output
 						derivedComponents, err := s.FindComponentsDerivedFromBaseComponents(stackFileName, terraformSection, components)
 						if err != nil {
-							return nil, err
+							return nil, nil, err
 						}
 
 						// Component vars
@@ -141,7 +156,7 @@ func ExecuteDescribeStacks(
 							context = cfg.GetContextFromVars(varsSection)
 							stackName, err = cfg.GetContextPrefix(stackFileName, context, cliConfig.Stacks.NamePattern, stackFileName)
 							if err != nil {
-								return nil, err
+								return nil, nil, err
 							}
 						}
 
@@ -187,7 +202,7 @@ func ExecuteDescribeStacks(
 										context,
 									)
 									if err != nil {
-										return nil, err
+										return nil, nil, err
 									}
 
 									finalStacksMap[stackName].(map[string]any)["components"].(map[string]any)["terraform"].(map[string]any)[componentName].(map[string]any)["workspace"] = workspace
@@ -214,7 +229,7 @@ func ExecuteDescribeStacks(
 					for componentName, compSection := range helmfileSection {
 						componentSection, ok := compSection.(map[string]any)
 						if !ok {
-							return nil, fmt.Errorf("invalid 'components.helmfile.%s' section in the file '%s'", componentName, stackFileName)
+							return nil, nil, fmt.Errorf("invalid 'components.helmfile.%s' section in the file '%s'", componentName, stackFileName)
 						}
 
 						if comp, ok := componentSection["component"].(string); !ok || comp == "" {
@@ -224,7 +239,7 @@ func ExecuteDescribeStacks(
 						// Find all derived components of the provided components and include them in the output
 						derivedComponents, err := s.FindComponentsDerivedFromBaseComponents(stackFileName, helmfileSection, components)
 						if err != nil {
-							return nil, err
+							return nil, nil, err
 						}
 
 						// Component vars
@@ -232,7 +247,7 @@ func ExecuteDescribeStacks(
 							context := cfg.GetContextFromVars(varsSection)
 							stackName, err = cfg.GetContextPrefix(stackFileName, context, cliConfig.Stacks.NamePattern, stackFileName)
 							if err != nil {
-								return nil, err
+								return nil, nil, err
 							}
 						}
 
@@ -289,5 +304,5 @@ func ExecuteDescribeStacks(
 		}
 	}
 
-	return finalStacksMap, nil
+	return finalStacksMap, rawStackConfigs, nil
 }
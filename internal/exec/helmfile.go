@@ -66,6 +66,12 @@ func ExecuteHelmfile(info schema.ConfigAndStacksInfo) error {
 			"by 'metadata.type: abstract' attribute", path.Join(info.ComponentFolderPrefix, info.Component))
 	}
 
+	// Check if the component is enabled in the stack (`metadata.enabled` and `metadata.enabled_when` attributes)
+	if (info.SubCommand == "sync" || info.SubCommand == "apply" || info.SubCommand == "deploy") && !info.ComponentIsEnabled {
+		return fmt.Errorf("component '%s' cannot be provisioned in the stack '%s' since it's disabled "+
+			"by the 'metadata.enabled' or 'metadata.enabled_when' attribute", info.ComponentFromArg, info.Stack)
+	}
+
 	// Print component variables
 	u.LogDebug(cliConfig, fmt.Sprintf("\nVariables for the component '%s' in the stack '%s':", info.ComponentFromArg, info.Stack))
 
@@ -77,6 +83,10 @@ func ExecuteHelmfile(info schema.ConfigAndStacksInfo) error {
 	}
 
 	// Check if component 'settings.validation' section is specified and validate the component
+	if info.NoValidationCache {
+		cliConfig.Validate.Cache.Disabled = true
+	}
+
 	valid, err := ValidateComponent(cliConfig, info.ComponentFromArg, info.ComponentSection, "", "", nil, 0)
 	if err != nil {
 		return err
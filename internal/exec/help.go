@@ -22,6 +22,8 @@ func processHelp(componentType string, command string) error {
 			u.PrintMessage(" - before executing other 'terraform' commands, 'atmos' runs 'terraform init'")
 			u.PrintMessage(" - you can skip over atmos calling 'terraform init' if you know your project is already in a good working state by using " +
 				"the '--skip-init' flag like so 'atmos terraform <command> <component> -s <stack> --skip-init")
+			u.PrintMessage(" - before executing the 'terraform' commands, 'atmos' validates the component using the policies from the " +
+				"'settings.validation' section. The successful results are cached, use the '--no-validation-cache' flag to evaluate the policies again")
 			u.PrintMessage(" - 'atmos terraform deploy' command executes 'terraform apply -auto-approve' (sets the '-auto-approve' flag when running 'terraform apply')")
 			u.PrintMessage(" - 'atmos terraform deploy' command supports '--deploy-run-init=true/false' flag to enable/disable running 'terraform init' " +
 				"before executing the command")
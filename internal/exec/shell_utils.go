@@ -70,6 +70,34 @@ func ExecuteShellCommand(
 	return cmd.Run()
 }
 
+// ExecuteShellCommandAndReturnOutput executes the provided command with args and flags, and returns its standard output.
+// The command is logged at the debug level
+func ExecuteShellCommandAndReturnOutput(
+	cliConfig schema.CliConfiguration,
+	command string,
+	args []string,
+	dir string,
+	env []string,
+) (string, error) {
+	var b bytes.Buffer
+
+	cmd := exec.Command(command, args...)
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Dir = dir
+	cmd.Stdout = &b
+	cmd.Stderr = os.Stderr
+
+	u.LogDebug(cliConfig, "\nExecuting command:")
+	u.LogDebug(cliConfig, cmd.String())
+
+	err := cmd.Run()
+	if err != nil {
+		return "", err
+	}
+
+	return b.String(), nil
+}
+
 // ExecuteShell runs a shell script
 func ExecuteShell(
 	cliConfig schema.CliConfiguration,
@@ -0,0 +1,288 @@
+package exec
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	cfg "github.com/cloudposse/atmos/pkg/config"
+	"github.com/cloudposse/atmos/pkg/schema"
+	s "github.com/cloudposse/atmos/pkg/stack"
+	u "github.com/cloudposse/atmos/pkg/utils"
+)
+
+// spaceliftStackAttributes are the `settings.spacelift` attributes that are passed as is to the `spacelift_stack` Terraform resource
+var spaceliftStackAttributes = []string{
+	"additional_project_globs",
+	"administrative",
+	"after_apply",
+	"after_destroy",
+	"after_init",
+	"after_perform",
+	"after_plan",
+	"autodeploy",
+	"autoretry",
+	"before_apply",
+	"before_destroy",
+	"before_init",
+	"before_perform",
+	"before_plan",
+	"description",
+	"enable_local_preview",
+	"manage_state",
+	"protect_from_deletion",
+	"runner_image",
+	"space_id",
+	"terraform_smart_sanitization",
+	"terraform_version",
+	"terraform_workflow_tool",
+	"worker_pool_id",
+}
+
+// invalidTerraformResourceNameChars matches the characters that are not allowed in Terraform resource names
+var invalidTerraformResourceNameChars = regexp.MustCompile(`[^a-zA-Z0-9_-]`)
+
+// ExecuteSpaceliftGenerateStacksCmd executes `spacelift generate stacks` command
+func ExecuteSpaceliftGenerateStacksCmd(cmd *cobra.Command, args []string) error {
+	info, err := processCommandLineArgs("", cmd, args, nil)
+	if err != nil {
+		return err
+	}
+
+	cliConfig, err := cfg.InitCliConfig(info, true)
+	if err != nil {
+		return err
+	}
+
+	flags := cmd.Flags()
+
+	format, err := flags.GetString("format")
+	if err != nil {
+		return err
+	}
+
+	if format != "" && format != "json" && format != "yaml" && format != "tf-json" {
+		return fmt.Errorf("invalid '--format' flag '%s'. Valid values are 'json' (default), 'yaml' and 'tf-json'", format)
+	}
+
+	if format == "" {
+		format = "json"
+	}
+
+	file, err := flags.GetString("file")
+	if err != nil {
+		return err
+	}
+
+	stackConfigPathTemplate, err := flags.GetString("stack-config-path-template")
+	if err != nil {
+		return err
+	}
+
+	repository, err := flags.GetString("repository")
+	if err != nil {
+		return err
+	}
+
+	branch, err := flags.GetString("branch")
+	if err != nil {
+		return err
+	}
+
+	spaceliftStacks, err := ExecuteSpaceliftGenerateStacks(cliConfig, stackConfigPathTemplate)
+	if err != nil {
+		return err
+	}
+
+	if format != "tf-json" {
+		return printOrWriteToFile(format, file, spaceliftStacks)
+	}
+
+	terraformJson, err := BuildSpaceliftTerraformResources(cliConfig, spaceliftStacks, repository, branch)
+	if err != nil {
+		return err
+	}
+
+	return printOrWriteToFile("json", file, terraformJson)
+}
+
+// ExecuteSpaceliftGenerateStacks processes all stack manifests and returns the map of Spacelift stack configs
+// (the same config that the Spacelift admin stack consumes)
+func ExecuteSpaceliftGenerateStacks(cliConfig schema.CliConfiguration, stackConfigPathTemplate string) (map[string]any, error) {
+	_, stacks, rawStackConfigs, err := s.ProcessYAMLConfigFiles(
+		cliConfig.StacksBaseAbsolutePath,
+		cliConfig.TerraformDirAbsolutePath,
+		cliConfig.HelmfileDirAbsolutePath,
+		cliConfig.StackConfigFilesAbsolutePaths,
+		true,
+		true,
+		false,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return TransformStackConfigToSpaceliftStacks(
+		stacks,
+		stackConfigPathTemplate,
+		cliConfig.Stacks.NamePattern,
+		true,
+		rawStackConfigs,
+	)
+}
+
+// BuildSpaceliftTerraformResources converts the Spacelift stack configs into the Terraform/OpenTofu JSON configuration
+// with the `spacelift_stack`, `spacelift_stack_dependency` and `spacelift_stack_destructor` resources.
+// The `repository` and `branch` are used for the stacks that don't specify them in the `settings.spacelift` section
+func BuildSpaceliftTerraformResources(
+	cliConfig schema.CliConfiguration,
+	spaceliftStacks map[string]any,
+	repository string,
+	branch string,
+) (map[string]any, error) {
+	stackResources := map[string]any{}
+	dependencyResources := map[string]any{}
+	destructorResources := map[string]any{}
+	// The Spacelift stack name of each Terraform resource name, to detect the names that are converted to the same resource name
+	resourceNames := map[string]string{}
+
+	for _, stackName := range u.StringKeysFromMap(spaceliftStacks) {
+		spaceliftConfig, ok := spaceliftStacks[stackName].(map[string]any)
+		if !ok {
+			continue
+		}
+
+		settingsSection, _ := spaceliftConfig["settings"].(map[any]any)
+		spaceliftSettings, _ := settingsSection["spacelift"].(map[any]any)
+		resourceName := terraformResourceName(stackName)
+
+		if other, ok := resourceNames[resourceName]; ok {
+			return nil, fmt.Errorf("the Spacelift stacks '%s' and '%s' have the same Terraform resource name '%s'. "+
+				"Check if the Spacelift stack name pattern is specific enough", other, stackName, resourceName)
+		}
+		resourceNames[resourceName] = stackName
+
+		stackRepository := repository
+		if v, ok := spaceliftSettings["repository"].(string); ok && v != "" {
+			stackRepository = v
+		}
+		if stackRepository == "" {
+			return nil, fmt.Errorf("the repository is not specified for the Spacelift stack '%s'. "+
+				"Specify it in the 'settings.spacelift.repository' section, or use the '--repository' flag", stackName)
+		}
+
+		stackBranch := branch
+		if v, ok := spaceliftSettings["branch"].(string); ok && v != "" {
+			stackBranch = v
+		}
+
+		// The component folder (relative to the repository root)
+		terraformComponent, _ := spaceliftConfig["base_component"].(string)
+		if terraformComponent == "" {
+			terraformComponent, _ = spaceliftConfig["component"].(string)
+		}
+		projectRoot := path.Join(cliConfig.Components.Terraform.BasePath, terraformComponent)
+		if v, ok := spaceliftSettings["component_root"].(string); ok && v != "" {
+			projectRoot = v
+		}
+
+		stackResource := map[string]any{
+			"name":                stackName,
+			"repository":          stackRepository,
+			"branch":              stackBranch,
+			"project_root":        projectRoot,
+			"terraform_workspace": spaceliftConfig["workspace"],
+			"labels":              spaceliftConfig["labels"],
+		}
+
+		for _, attribute := range spaceliftStackAttributes {
+			if v, ok := spaceliftSettings[attribute]; ok && v != nil {
+				stackResource[attribute] = v
+			}
+		}
+
+		stackResources[resourceName] = stackResource
+
+		if destructorEnabled, ok := spaceliftSettings["stack_destructor_enabled"].(bool); ok && destructorEnabled {
+			destructorResources[resourceName] = map[string]any{
+				"stack_id": fmt.Sprintf("${spacelift_stack.%s.id}", resourceName),
+			}
+		}
+
+		for _, dependsOnStackName := range findSpaceliftStackDependsOn(cliConfig, spaceliftStacks, stackName) {
+			dependsOnResourceName := terraformResourceName(dependsOnStackName)
+			dependencyResources[resourceName+"__"+dependsOnResourceName] = map[string]any{
+				"stack_id":            fmt.Sprintf("${spacelift_stack.%s.id}", resourceName),
+				"depends_on_stack_id": fmt.Sprintf("${spacelift_stack.%s.id}", dependsOnResourceName),
+			}
+		}
+	}
+
+	resources := map[string]any{
+		"spacelift_stack": stackResources,
+	}
+	if len(dependencyResources) > 0 {
+		resources["spacelift_stack_dependency"] = dependencyResources
+	}
+	if len(destructorResources) > 0 {
+		resources["spacelift_stack_destructor"] = destructorResources
+	}
+
+	result := map[string]any{
+		"terraform": map[string]any{
+			"required_providers": map[string]any{
+				"spacelift": map[string]any{
+					"source": "spacelift-io/spacelift",
+				},
+			},
+		},
+		"resource": resources,
+	}
+
+	return result, nil
+}
+
+// findSpaceliftStackDependsOn returns the sorted names of the Spacelift stacks that the Spacelift stack depends on
+// (from the `depends-on:<stack>` labels). The dependencies on the components that don't have Spacelift stacks
+// (`settings.spacelift.workspace_enabled` is not `true`, or the components are disabled or abstract) are not provisioned by Spacelift,
+// so they are skipped with a warning
+func findSpaceliftStackDependsOn(cliConfig schema.CliConfiguration, spaceliftStacks map[string]any, stackName string) []string {
+	spaceliftConfig, _ := spaceliftStacks[stackName].(map[string]any)
+	labels, _ := spaceliftConfig["labels"].([]string)
+
+	var dependsOn []string
+
+	for _, label := range labels {
+		dependsOnStackName, ok := strings.CutPrefix(label, "depends-on:")
+		if !ok {
+			continue
+		}
+
+		if _, ok := spaceliftStacks[dependsOnStackName]; !ok {
+			u.LogWarning(cliConfig, fmt.Sprintf("the Spacelift stack '%s' depends on '%s', which is not a Spacelift stack "+
+				"(the component is disabled, abstract, or 'settings.spacelift.workspace_enabled' is not 'true'). The dependency is skipped",
+				stackName, dependsOnStackName))
+			continue
+		}
+
+		dependsOn = append(dependsOn, dependsOnStackName)
+	}
+
+	dependsOn = u.UniqueStrings(dependsOn)
+	sort.Strings(dependsOn)
+
+	return dependsOn
+}
+
+// terraformResourceName converts the name (e.g. Spacelift stack or TFC workspace name) into a valid Terraform resource name
+func terraformResourceName(name string) string {
+	name = invalidTerraformResourceNameChars.ReplaceAllString(name, "_")
+	if name == "" || (name[0] >= '0' && name[0] <= '9') || name[0] == '-' {
+		name = "_" + name
+	}
+	return name
+}
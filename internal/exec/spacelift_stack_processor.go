@@ -0,0 +1,346 @@
+package exec
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/pkg/errors"
+
+	cfg "github.com/cloudposse/atmos/pkg/config"
+	"github.com/cloudposse/atmos/pkg/schema"
+	u "github.com/cloudposse/atmos/pkg/utils"
+)
+
+// TransformStackConfigToSpaceliftStacks takes a map of stack manifests and transforms it to a map of Spacelift stacks
+func TransformStackConfigToSpaceliftStacks(
+	stacks map[string]any,
+	stackConfigPathTemplate string,
+	stackNamePattern string,
+	processImports bool,
+	rawStackConfigs map[string]map[string]any,
+) (map[string]any, error) {
+
+	res, stackErrors, err := transformStackConfigToSpaceliftStacks(stacks, stackConfigPathTemplate, stackNamePattern, processImports, rawStackConfigs)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(stackErrors) > 0 {
+		u.LogError(stackErrors[0])
+		return nil, stackErrors[0]
+	}
+
+	return res, nil
+}
+
+// transformStackConfigToSpaceliftStacks takes a map of stack manifests and transforms it to a map of Spacelift stacks.
+// The errors in the Spacelift stack configs (the dependencies on the components that are not defined in the stacks,
+// and the duplicate Spacelift stack names) don't stop the processing, they are returned as the list of errors,
+// so `atmos validate spacelift` can report all of them
+func transformStackConfigToSpaceliftStacks(
+	stacks map[string]any,
+	stackConfigPathTemplate string,
+	stackNamePattern string,
+	processImports bool,
+	rawStackConfigs map[string]map[string]any,
+) (map[string]any, []error, error) {
+
+	var err error
+	var stackErrors []error
+	res := map[string]any{}
+
+	allStackNames, err := BuildSpaceliftStackNames(stacks, stackNamePattern)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Iterate not over the map itself, but over the sorted map keys since Go iterates over maps in random order
+	for _, stackName := range u.StringKeysFromMap(stacks) {
+		config := stacks[stackName].(map[any]any)
+		var imports []string
+
+		if processImports {
+			if i, ok := config["imports"]; ok {
+				imports = i.([]string)
+			}
+		}
+
+		if i, ok := config["components"]; ok {
+			componentsSection := i.(map[string]any)
+
+			if terraformComponents, ok := componentsSection["terraform"]; ok {
+				terraformComponentsMap := terraformComponents.(map[string]any)
+
+				for _, component := range u.StringKeysFromMap(terraformComponentsMap) {
+					componentMap := terraformComponentsMap[component].(map[string]any)
+
+					componentSettings := map[any]any{}
+					if i, ok2 := componentMap["settings"]; ok2 {
+						componentSettings = i.(map[any]any)
+					}
+
+					spaceliftSettings := map[any]any{}
+					spaceliftWorkspaceEnabled := false
+
+					if i, ok2 := componentSettings["spacelift"]; ok2 {
+						spaceliftSettings = i.(map[any]any)
+
+						if i3, ok3 := spaceliftSettings["workspace_enabled"]; ok3 {
+							spaceliftWorkspaceEnabled = i3.(bool)
+						}
+					}
+
+					// If Spacelift workspace is disabled, don't include it, continue to the next component
+					if !spaceliftWorkspaceEnabled {
+						continue
+					}
+
+					spaceliftExplicitLabels := []any{}
+					if i, ok2 := spaceliftSettings["labels"]; ok2 {
+						spaceliftExplicitLabels = i.([]any)
+					}
+
+					spaceliftConfig := map[string]any{}
+					spaceliftConfig["enabled"] = spaceliftWorkspaceEnabled
+
+					componentVars := map[any]any{}
+					if i, ok2 := componentMap["vars"]; ok2 {
+						componentVars = i.(map[any]any)
+					}
+
+					componentEnv := map[any]any{}
+					if i, ok2 := componentMap["env"]; ok2 {
+						componentEnv = i.(map[any]any)
+					}
+
+					componentStacks := []string{}
+					if i, ok2 := componentMap["stacks"]; ok2 {
+						componentStacks = i.([]string)
+					}
+
+					componentInheritance := []string{}
+					if i, ok2 := componentMap["inheritance"]; ok2 {
+						componentInheritance = i.([]string)
+					}
+
+					// Process component metadata and find a base component (if any) and whether the component is real or abstract
+					componentMetadata, baseComponentName, componentIsAbstract := ProcessComponentMetadata(component, componentMap)
+
+					if componentIsAbstract {
+						continue
+					}
+
+					// Don't create Spacelift stacks for the components disabled by `metadata.enabled` or `metadata.enabled_when`
+					if !IsComponentEnabled(componentMetadata, componentVars) {
+						continue
+					}
+
+					context := cfg.GetContextFromVars(componentVars)
+					context.Component = component
+					context.BaseComponent = baseComponentName
+
+					var contextPrefix string
+
+					if stackNamePattern != "" {
+						contextPrefix, err = cfg.GetContextPrefix(stackName, context, stackNamePattern, stackName)
+						if err != nil {
+							u.LogError(err)
+							return nil, nil, err
+						}
+					} else {
+						contextPrefix = strings.Replace(stackName, "/", "-", -1)
+					}
+
+					spaceliftConfig["component"] = component
+					spaceliftConfig["stack"] = contextPrefix
+					spaceliftConfig["imports"] = imports
+					spaceliftConfig["vars"] = componentVars
+					spaceliftConfig["settings"] = componentSettings
+					spaceliftConfig["env"] = componentEnv
+					spaceliftConfig["stacks"] = componentStacks
+					spaceliftConfig["inheritance"] = componentInheritance
+					spaceliftConfig["base_component"] = baseComponentName
+					spaceliftConfig["metadata"] = componentMetadata
+
+					// backend
+					backendTypeName := ""
+					if backendType, backendTypeExist := componentMap["backend_type"]; backendTypeExist {
+						backendTypeName = backendType.(string)
+					}
+					spaceliftConfig["backend_type"] = backendTypeName
+
+					componentBackend := map[any]any{}
+					if i, ok2 := componentMap["backend"]; ok2 {
+						componentBackend = i.(map[any]any)
+					}
+					spaceliftConfig["backend"] = componentBackend
+
+					// Component dependencies
+					configAndStacksInfo := schema.ConfigAndStacksInfo{
+						ComponentFromArg:          component,
+						ComponentType:             "terraform",
+						StackFile:                 stackName,
+						ComponentVarsSection:      componentVars,
+						ComponentEnvSection:       componentEnv,
+						ComponentSettingsSection:  componentSettings,
+						ComponentBackendSection:   componentBackend,
+						ComponentBackendType:      backendTypeName,
+						ComponentInheritanceChain: componentInheritance,
+					}
+
+					sources, err := ProcessConfigSources(configAndStacksInfo, rawStackConfigs)
+					if err != nil {
+						return nil, nil, err
+					}
+
+					componentDeps, componentDepsAll, err := FindComponentDependencies(stackName, sources)
+					if err != nil {
+						return nil, nil, err
+					}
+
+					spaceliftConfig["deps"] = componentDeps
+					spaceliftConfig["deps_all"] = componentDepsAll
+
+					// Terraform workspace
+					workspace, err := BuildTerraformWorkspace(
+						stackName,
+						stackNamePattern,
+						componentMetadata,
+						context,
+					)
+					if err != nil {
+						u.LogError(err)
+						return nil, nil, err
+					}
+					spaceliftConfig["workspace"] = workspace
+
+					// labels
+					labels := []string{}
+					for _, v := range imports {
+						labels = append(labels, fmt.Sprintf("import:"+stackConfigPathTemplate, v))
+					}
+					for _, v := range componentStacks {
+						labels = append(labels, fmt.Sprintf("stack:"+stackConfigPathTemplate, v))
+					}
+					for _, v := range componentDeps {
+						labels = append(labels, fmt.Sprintf("deps:"+stackConfigPathTemplate, v))
+					}
+					for _, v := range spaceliftExplicitLabels {
+						labels = append(labels, v.(string))
+					}
+
+					var terraformComponentNamesInCurrentStack []string
+
+					for v2 := range terraformComponentsMap {
+						terraformComponentNamesInCurrentStack = append(terraformComponentNamesInCurrentStack, strings.Replace(v2, "/", "-", -1))
+					}
+
+					// Legacy/deprecated `settings.spacelift.depends_on`
+					spaceliftDependsOn := []any{}
+					if i, ok2 := spaceliftSettings["depends_on"]; ok2 {
+						spaceliftDependsOn = i.([]any)
+					}
+
+					var spaceliftStackNameDependsOnLabels1 []string
+
+					for _, dep := range spaceliftDependsOn {
+						spaceliftStackNameDependsOn, err := BuildDependentStackNameFromDependsOnLegacy(
+							dep.(string),
+							allStackNames,
+							contextPrefix,
+							terraformComponentNamesInCurrentStack,
+							component,
+						)
+						if err != nil {
+							stackErrors = append(stackErrors, err)
+							continue
+						}
+						spaceliftStackNameDependsOnLabels1 = append(spaceliftStackNameDependsOnLabels1, fmt.Sprintf("depends-on:%s", spaceliftStackNameDependsOn))
+					}
+
+					sort.Strings(spaceliftStackNameDependsOnLabels1)
+					labels = append(labels, spaceliftStackNameDependsOnLabels1...)
+
+					// Recommended `settings.depends_on`
+					var stackComponentSettingsDependsOn schema.Settings
+					err = mapstructure.Decode(componentSettings, &stackComponentSettingsDependsOn)
+					if err != nil {
+						return nil, nil, err
+					}
+
+					var spaceliftStackNameDependsOnLabels2 []string
+
+					for _, stackComponentSettingsDependsOnContext := range stackComponentSettingsDependsOn.DependsOn {
+						if stackComponentSettingsDependsOnContext.Component == "" {
+							continue
+						}
+
+						stackComponentSettingsDependsOnContext = dependsOnContext(stackComponentSettingsDependsOnContext, context)
+
+						var contextPrefixDependsOn string
+
+						if stackNamePattern != "" {
+							contextPrefixDependsOn, err = cfg.GetContextPrefix(
+								stackName,
+								stackComponentSettingsDependsOnContext,
+								stackNamePattern,
+								stackName,
+							)
+							if err != nil {
+								return nil, nil, err
+							}
+						} else {
+							contextPrefixDependsOn = strings.Replace(stackName, "/", "-", -1)
+						}
+
+						spaceliftStackNameDependsOn, err := BuildDependentStackNameFromDependsOn(
+							component,
+							contextPrefix,
+							stackComponentSettingsDependsOnContext.Component,
+							contextPrefixDependsOn,
+							allStackNames,
+						)
+						if err != nil {
+							stackErrors = append(stackErrors, err)
+							continue
+						}
+						spaceliftStackNameDependsOnLabels2 = append(spaceliftStackNameDependsOnLabels2, fmt.Sprintf("depends-on:%s", spaceliftStackNameDependsOn))
+					}
+
+					sort.Strings(spaceliftStackNameDependsOnLabels2)
+					labels = append(labels, spaceliftStackNameDependsOnLabels2...)
+
+					// Add `component` and `folder` labels
+					labels = append(labels, fmt.Sprintf("folder:component/%s", component))
+					labels = append(labels, fmt.Sprintf("folder:%s", strings.Replace(contextPrefix, "-", "/", -1)))
+
+					spaceliftConfig["labels"] = u.UniqueStrings(labels)
+
+					// Spacelift stack name
+					spaceliftStackName, spaceliftStackNamePattern := BuildSpaceliftStackName(spaceliftSettings, context, contextPrefix)
+
+					// Add Spacelift stack config to the final map
+					spaceliftStackNameKey := strings.Replace(spaceliftStackName, "/", "-", -1)
+
+					if !u.MapKeyExists(res, spaceliftStackNameKey) {
+						res[spaceliftStackNameKey] = spaceliftConfig
+					} else {
+						errorMessage := fmt.Sprintf("\nDuplicate Spacelift stack name '%s' for component '%s' in the stack '%s'."+
+							"\nCheck if the component name is correct and the Spacelift stack name pattern 'stack_name_pattern=%s' is specific enough."+
+							"\nDid you specify the correct context tokens {namespace}, {tenant}, {environment}, {stage}, {component}?",
+							spaceliftStackName,
+							component,
+							stackName,
+							spaceliftStackNamePattern,
+						)
+						stackErrors = append(stackErrors, errors.New(errorMessage))
+					}
+				}
+			}
+		}
+	}
+
+	return res, stackErrors, nil
+}
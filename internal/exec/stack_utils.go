@@ -83,6 +83,82 @@ func ProcessComponentMetadata(
 	return componentMetadata, baseComponentName, componentIsAbstract
 }
 
+// IsComponentEnabled checks if the component is enabled using the `metadata.enabled` and `metadata.enabled_when` attributes.
+// `metadata.enabled_when` is a map of context variables (from the component's `vars` section) to a value or a list of allowed values, e.g.:
+//
+//	metadata:
+//	  enabled_when:
+//	    stage: prod
+//	    region: [us-east-2, us-west-2]
+//
+// The component is enabled only if all the conditions are satisfied
+func IsComponentEnabled(componentMetadata map[any]any, componentVars map[any]any) bool {
+	if enabled, ok := componentMetadata["enabled"].(bool); ok && !enabled {
+		return false
+	}
+
+	enabledWhen, ok := componentMetadata["enabled_when"].(map[any]any)
+	if !ok {
+		return true
+	}
+
+	for k, v := range enabledWhen {
+		varValue, ok := componentVars[k]
+		if !ok {
+			return false
+		}
+
+		var allowedValues []any
+		if l, ok := v.([]any); ok {
+			allowedValues = l
+		} else {
+			allowedValues = []any{v}
+		}
+
+		matched := false
+		for _, allowedValue := range allowedValues {
+			if fmt.Sprintf("%v", allowedValue) == fmt.Sprintf("%v", varValue) {
+				matched = true
+				break
+			}
+		}
+
+		if !matched {
+			return false
+		}
+	}
+
+	return true
+}
+
+// componentStackName returns the stack name from the context and the stack name pattern,
+// or the stack config file name if the stack name pattern is not configured
+func componentStackName(cliConfig schema.CliConfiguration, stackConfigFileName string, context schema.Context) (string, error) {
+	if cliConfig.Stacks.NamePattern == "" {
+		return strings.Replace(stackConfigFileName, "/", "-", -1), nil
+	}
+	return cfg.GetContextPrefix(stackConfigFileName, context, cliConfig.Stacks.NamePattern, stackConfigFileName)
+}
+
+// dependsOnContext returns the context of the component from the `settings.depends_on` section.
+// The context attributes (namespace, tenant, environment, stage) not specified in `depends_on` are taken from the context
+// of the dependent component, so by default the dependency is in the same stack as the dependent component
+func dependsOnContext(dependsOn schema.Context, context schema.Context) schema.Context {
+	if dependsOn.Namespace == "" {
+		dependsOn.Namespace = context.Namespace
+	}
+	if dependsOn.Tenant == "" {
+		dependsOn.Tenant = context.Tenant
+	}
+	if dependsOn.Environment == "" {
+		dependsOn.Environment = context.Environment
+	}
+	if dependsOn.Stage == "" {
+		dependsOn.Stage = context.Stage
+	}
+	return dependsOn
+}
+
 // BuildDependentStackNameFromDependsOnLegacy builds the dependent stack name from "settings.spacelift.depends_on" config
 func BuildDependentStackNameFromDependsOnLegacy(
 	dependsOn string,
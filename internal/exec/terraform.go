@@ -73,6 +73,12 @@ func ExecuteTerraform(info schema.ConfigAndStacksInfo) error {
 			"by 'metadata.type: abstract' attribute", path.Join(info.ComponentFolderPrefix, info.Component))
 	}
 
+	// Check if the component is enabled in the stack (`metadata.enabled` and `metadata.enabled_when` attributes)
+	if (info.SubCommand == "plan" || info.SubCommand == "apply" || info.SubCommand == "deploy" || info.SubCommand == "workspace") && !info.ComponentIsEnabled {
+		return fmt.Errorf("component '%s' cannot be provisioned in the stack '%s' since it's disabled "+
+			"by the 'metadata.enabled' or 'metadata.enabled_when' attribute", info.ComponentFromArg, info.Stack)
+	}
+
 	varFile := constructTerraformComponentVarfileName(info)
 	planFile := constructTerraformComponentPlanfileName(info)
 
@@ -172,6 +178,10 @@ func ExecuteTerraform(info schema.ConfigAndStacksInfo) error {
 	}
 
 	// Check if component 'settings.validation' section is specified and validate the component
+	if info.NoValidationCache {
+		cliConfig.Validate.Cache.Disabled = true
+	}
+
 	valid, err := ValidateComponent(cliConfig, info.ComponentFromArg, info.ComponentSection, "", "", nil, 0)
 	if err != nil {
 		return err
@@ -0,0 +1,118 @@
+package exec
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	cfg "github.com/cloudposse/atmos/pkg/config"
+	u "github.com/cloudposse/atmos/pkg/utils"
+)
+
+// ExecuteTerraformGenerateSchemaCmd executes `terraform generate schema` command
+func ExecuteTerraformGenerateSchemaCmd(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return errors.New("invalid arguments. The command requires one argument `component`")
+	}
+
+	flags := cmd.Flags()
+
+	stack, err := flags.GetString("stack")
+	if err != nil {
+		return err
+	}
+
+	file, err := flags.GetString("file")
+	if err != nil {
+		return err
+	}
+
+	component := args[0]
+
+	info, err := processCommandLineArgs("terraform", cmd, args, nil)
+	if err != nil {
+		return err
+	}
+
+	info.ComponentFromArg = component
+	info.Stack = stack
+	info.ComponentType = "terraform"
+
+	cliConfig, err := cfg.InitCliConfig(info, true)
+	if err != nil {
+		return err
+	}
+
+	// If the stack is specified, the argument is an Atmos component, and the schema is generated for the Terraform component it points to.
+	// Otherwise, the argument is the Terraform component (the folder relative to `components.terraform.base_path`)
+	var terraformComponent string
+	var componentPath string
+
+	if stack != "" {
+		info, err = ProcessStacks(cliConfig, info, true)
+		if err != nil {
+			return err
+		}
+		terraformComponent = info.FinalComponent
+		componentPath = constructTerraformComponentWorkingDir(cliConfig, info)
+	} else {
+		terraformComponent = component
+		componentPath = path.Join(cliConfig.BasePath, cliConfig.Components.Terraform.BasePath, component)
+	}
+
+	jsonSchema, err := ExecuteTerraformGenerateSchema(terraformComponent, componentPath)
+	if err != nil {
+		return err
+	}
+
+	out, err := terraformComponentJsonSchemaToString(jsonSchema)
+	if err != nil {
+		return err
+	}
+
+	if file == "" {
+		u.PrintMessage(out)
+		return nil
+	}
+
+	u.LogDebug(cliConfig, fmt.Sprintf("Writing the JSON Schema for the Terraform component '%s' to the file '%s'", terraformComponent, file))
+
+	err = u.EnsureDir(file)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(file, []byte(out), 0644)
+}
+
+// terraformComponentJsonSchemaToString renders the JSON Schema with sorted keys and 2-space indentation
+func terraformComponentJsonSchemaToString(jsonSchema map[string]any) (string, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(jsonSchema); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// ExecuteTerraformGenerateSchema generates a JSON Schema for the `vars` section of the Terraform component in the folder `componentPath`
+// from the variables declared in the component
+func ExecuteTerraformGenerateSchema(terraformComponent string, componentPath string) (map[string]any, error) {
+	if !u.FileOrDirExists(componentPath) {
+		return nil, fmt.Errorf("the Terraform component '%s' does not exist in the folder '%s'", terraformComponent, componentPath)
+	}
+
+	variables, err := loadTerraformVariables(componentPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read the variables of the Terraform component '%s'", terraformComponent)
+	}
+
+	return terraformVariablesToJsonSchema(terraformComponent, variables), nil
+}
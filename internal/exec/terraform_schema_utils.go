@@ -0,0 +1,555 @@
+package exec
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/ext/typeexpr"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/pkg/errors"
+	"github.com/santhosh-tekuri/jsonschema/v5"
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+
+	u "github.com/cloudposse/atmos/pkg/utils"
+)
+
+// terraformVariable is a variable declared in the `variable` block in a Terraform component
+type terraformVariable struct {
+	Name        string
+	Type        cty.Type
+	Description string
+	Default     any
+	HasDefault  bool
+	Nullable    bool
+	Validations []terraformVariableValidation
+}
+
+// terraformVariableValidation is a `validation` block of a Terraform variable
+type terraformVariableValidation struct {
+	Condition    hclsyntax.Expression
+	ErrorMessage string
+}
+
+// loadTerraformVariables parses all `.tf` files in the Terraform component folder and returns the declared variables sorted by name
+func loadTerraformVariables(componentPath string) ([]terraformVariable, error) {
+	entries, err := os.ReadDir(componentPath)
+	if err != nil {
+		return nil, err
+	}
+
+	parser := hclparse.NewParser()
+	var variables []terraformVariable
+
+	for _, entry := range entries {
+		if entry.IsDir() || path.Ext(entry.Name()) != ".tf" {
+			continue
+		}
+
+		filePath := path.Join(componentPath, entry.Name())
+
+		file, diags := parser.ParseHCLFile(filePath)
+		if diags.HasErrors() {
+			return nil, errors.New(diags.Error())
+		}
+
+		body, ok := file.Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+
+		for _, block := range body.Blocks {
+			if block.Type != "variable" || len(block.Labels) != 1 {
+				continue
+			}
+
+			variable, err := parseTerraformVariable(block)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid variable '%s' in the file '%s'", block.Labels[0], filePath)
+			}
+
+			variables = append(variables, variable)
+		}
+	}
+
+	sort.Slice(variables, func(i, j int) bool {
+		return variables[i].Name < variables[j].Name
+	})
+
+	return variables, nil
+}
+
+// parseTerraformVariable parses the `variable` block
+func parseTerraformVariable(block *hclsyntax.Block) (terraformVariable, error) {
+	variable := terraformVariable{
+		Name:     block.Labels[0],
+		Type:     cty.DynamicPseudoType,
+		Nullable: true,
+	}
+
+	if attr, ok := block.Body.Attributes["type"]; ok {
+		ty, _, diags := typeexpr.TypeConstraintWithDefaults(attr.Expr)
+		if diags.HasErrors() {
+			return variable, errors.New(diags.Error())
+		}
+		variable.Type = ty
+	}
+
+	if attr, ok := block.Body.Attributes["description"]; ok {
+		if v, diags := attr.Expr.Value(nil); !diags.HasErrors() && v.Type() == cty.String && v.IsKnown() && !v.IsNull() {
+			variable.Description = strings.TrimSpace(v.AsString())
+		}
+	}
+
+	if attr, ok := block.Body.Attributes["nullable"]; ok {
+		if v, diags := attr.Expr.Value(nil); !diags.HasErrors() && v.Type() == cty.Bool && v.IsKnown() && !v.IsNull() {
+			variable.Nullable = v.True()
+		}
+	}
+
+	if attr, ok := block.Body.Attributes["default"]; ok {
+		variable.HasDefault = true
+
+		// The default values that can't be evaluated without the Terraform context (e.g. function calls) are not added to the schema
+		if v, diags := attr.Expr.Value(nil); !diags.HasErrors() {
+			if d, err := ctyValueToJsonValue(v); err == nil {
+				variable.Default = d
+			}
+		}
+	}
+
+	for _, b := range block.Body.Blocks {
+		if b.Type != "validation" {
+			continue
+		}
+
+		validation := terraformVariableValidation{}
+
+		if attr, ok := b.Body.Attributes["condition"]; ok {
+			validation.Condition = attr.Expr
+		}
+
+		if attr, ok := b.Body.Attributes["error_message"]; ok {
+			if v, diags := attr.Expr.Value(nil); !diags.HasErrors() && v.Type() == cty.String && v.IsKnown() && !v.IsNull() {
+				validation.ErrorMessage = v.AsString()
+			}
+		}
+
+		variable.Validations = append(variable.Validations, validation)
+	}
+
+	return variable, nil
+}
+
+// ctyValueToJsonValue converts the cty value to a Go value with the JSON data types
+func ctyValueToJsonValue(v cty.Value) (any, error) {
+	if !v.IsWhollyKnown() {
+		return nil, fmt.Errorf("the value is not known")
+	}
+
+	b, err := ctyjson.Marshal(v, v.Type())
+	if err != nil {
+		return nil, err
+	}
+
+	var res any
+	if err = json.Unmarshal(b, &res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// terraformVariablesToJsonSchema generates a JSON Schema (draft 2020-12) for the component config from the Terraform variables.
+// The `vars` section must contain only the declared variables, and all the variables without default values are required.
+// The schema can be used in the `settings.validation` section of the component
+func terraformVariablesToJsonSchema(component string, variables []terraformVariable) map[string]any {
+	properties := map[string]any{}
+	required := []string{}
+
+	for _, v := range variables {
+		properties[v.Name] = terraformVariableToJsonSchema(v)
+		if !v.HasDefault {
+			required = append(required, v.Name)
+		}
+	}
+
+	return map[string]any{
+		"$schema":     "https://json-schema.org/draft/2020-12/schema",
+		"title":       fmt.Sprintf("%s component validation", component),
+		"description": fmt.Sprintf("JSON Schema for the 'vars' section of the '%s' Terraform component generated from the component's variables", component),
+		"type":        "object",
+		"properties": map[string]any{
+			"vars": map[string]any{
+				"type":                 "object",
+				"properties":           properties,
+				"required":             required,
+				"additionalProperties": false,
+			},
+		},
+	}
+}
+
+// terraformVariableToJsonSchema generates the JSON Schema for the Terraform variable
+func terraformVariableToJsonSchema(v terraformVariable) map[string]any {
+	s := ctyTypeToJsonSchema(v.Type)
+
+	if v.Description != "" {
+		s["description"] = v.Description
+	}
+
+	if v.HasDefault && v.Default != nil {
+		s["default"] = v.Default
+	}
+
+	var comments []string
+
+	for _, validation := range v.Validations {
+		constraints, ok := terraformValidationToJsonSchema(v, validation.Condition)
+		if !ok {
+			// The conditions that can't be expressed in JSON Schema are checked by Terraform
+			comment := validation.ErrorMessage
+			if comment == "" {
+				comment = "validation condition"
+			}
+			comments = append(comments, fmt.Sprintf("Not checked by the schema: %s", comment))
+			continue
+		}
+		for k, c := range constraints {
+			s[k] = c
+		}
+	}
+
+	if len(comments) > 0 {
+		s["$comment"] = strings.Join(comments, "\n")
+	}
+
+	// Terraform variables accept `null` unless `nullable = false`
+	if v.Nullable {
+		allowNullInJsonSchema(s)
+	}
+
+	return s
+}
+
+// ctyTypeToJsonSchema converts the Terraform type constraint to JSON Schema
+func ctyTypeToJsonSchema(t cty.Type) map[string]any {
+	switch {
+	case t == cty.DynamicPseudoType:
+		return map[string]any{}
+	case t == cty.String:
+		// Terraform converts numbers and booleans to strings
+		return map[string]any{"type": []any{"string", "number", "boolean"}}
+	case t == cty.Number:
+		return map[string]any{"type": "number"}
+	case t == cty.Bool:
+		return map[string]any{"type": "boolean"}
+	case t.IsListType(), t.IsSetType():
+		s := map[string]any{"type": "array"}
+		if items := ctyTypeToJsonSchema(t.ElementType()); len(items) > 0 {
+			s["items"] = items
+		}
+		return s
+	case t.IsMapType():
+		s := map[string]any{"type": "object"}
+		if items := ctyTypeToJsonSchema(t.ElementType()); len(items) > 0 {
+			s["additionalProperties"] = items
+		}
+		return s
+	case t.IsTupleType():
+		var items []any
+		for _, et := range t.TupleElementTypes() {
+			items = append(items, ctyTypeToJsonSchema(et))
+		}
+		return map[string]any{
+			"type":        "array",
+			"prefixItems": items,
+			"minItems":    len(items),
+			"maxItems":    len(items),
+		}
+	case t.IsObjectType():
+		properties := map[string]any{}
+		required := []string{}
+		var names []string
+		for name := range t.AttributeTypes() {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			properties[name] = ctyTypeToJsonSchema(t.AttributeType(name))
+			if !t.AttributeOptional(name) {
+				required = append(required, name)
+			}
+		}
+		return map[string]any{
+			"type":       "object",
+			"properties": properties,
+			"required":   required,
+		}
+	default:
+		return map[string]any{}
+	}
+}
+
+// allowNullInJsonSchema adds `null` to the types and the enum values in the JSON Schema
+func allowNullInJsonSchema(s map[string]any) {
+	switch t := s["type"].(type) {
+	case string:
+		s["type"] = []any{t, "null"}
+	case []any:
+		s["type"] = append(t, "null")
+	}
+
+	if enum, ok := s["enum"].([]any); ok {
+		s["enum"] = append(enum, nil)
+	}
+}
+
+// terraformValidationToJsonSchema converts the condition of the `validation` block to the JSON Schema keywords.
+// The following conditions are supported:
+//
+//	contains(["a", "b"], var.x)
+//	can(regex("^[a-z]+$", var.x))
+//	var.x >= 1, var.x < 10
+//	length(var.x) > 0, length(var.x) <= 3
+//	<condition> && <condition>
+//	var.x == null ? true : <condition>
+func terraformValidationToJsonSchema(v terraformVariable, expr hclsyntax.Expression) (map[string]any, bool) {
+	switch e := expr.(type) {
+	case *hclsyntax.ParenthesesExpr:
+		return terraformValidationToJsonSchema(v, e.Expression)
+
+	case *hclsyntax.ConditionalExpr:
+		// `var.x == null ? true : <condition>` - `null` is allowed by the schema if the variable is nullable
+		cond, ok := e.Condition.(*hclsyntax.BinaryOpExpr)
+		if !ok || cond.Op != hclsyntax.OpEqual || !isTerraformVariableReference(cond.LHS, v.Name) || !isLiteralValue(cond.RHS, cty.NullVal(cty.DynamicPseudoType)) {
+			return nil, false
+		}
+		if !isLiteralValue(e.TrueResult, cty.True) {
+			return nil, false
+		}
+		return terraformValidationToJsonSchema(v, e.FalseResult)
+
+	case *hclsyntax.BinaryOpExpr:
+		if e.Op == hclsyntax.OpLogicalAnd {
+			lhs, ok := terraformValidationToJsonSchema(v, e.LHS)
+			if !ok {
+				return nil, false
+			}
+			rhs, ok := terraformValidationToJsonSchema(v, e.RHS)
+			if !ok {
+				return nil, false
+			}
+			for k, c := range rhs {
+				if _, exists := lhs[k]; exists {
+					return nil, false
+				}
+				lhs[k] = c
+			}
+			return lhs, true
+		}
+		return terraformComparisonToJsonSchema(v, e)
+
+	case *hclsyntax.FunctionCallExpr:
+		switch e.Name {
+		case "contains":
+			if len(e.Args) != 2 || !isTerraformVariableReference(e.Args[1], v.Name) {
+				return nil, false
+			}
+			values, diags := e.Args[0].Value(nil)
+			if diags.HasErrors() || !(values.Type().IsTupleType() || values.Type().IsListType() || values.Type().IsSetType()) {
+				return nil, false
+			}
+			enum, err := ctyValueToJsonValue(values)
+			if err != nil {
+				return nil, false
+			}
+			return map[string]any{"enum": enum}, true
+
+		case "can":
+			if len(e.Args) != 1 {
+				return nil, false
+			}
+			regex, ok := e.Args[0].(*hclsyntax.FunctionCallExpr)
+			if !ok || regex.Name != "regex" || len(regex.Args) != 2 || !isTerraformVariableReference(regex.Args[1], v.Name) {
+				return nil, false
+			}
+			pattern, diags := regex.Args[0].Value(nil)
+			if diags.HasErrors() || pattern.Type() != cty.String || pattern.IsNull() {
+				return nil, false
+			}
+			return map[string]any{"pattern": pattern.AsString()}, true
+		}
+	}
+
+	return nil, false
+}
+
+// terraformComparisonToJsonSchema converts the comparison of the variable (or its length) with a number to the JSON Schema keywords
+func terraformComparisonToJsonSchema(v terraformVariable, e *hclsyntax.BinaryOpExpr) (map[string]any, bool) {
+	op := e.Op
+	lhs := e.LHS
+	rhs := e.RHS
+
+	// Normalize `1 <= var.x` to `var.x >= 1`
+	if _, ok := lhs.(*hclsyntax.LiteralValueExpr); ok {
+		lhs, rhs = rhs, lhs
+		switch op {
+		case hclsyntax.OpGreaterThan:
+			op = hclsyntax.OpLessThan
+		case hclsyntax.OpGreaterThanOrEqual:
+			op = hclsyntax.OpLessThanOrEqual
+		case hclsyntax.OpLessThan:
+			op = hclsyntax.OpGreaterThan
+		case hclsyntax.OpLessThanOrEqual:
+			op = hclsyntax.OpGreaterThanOrEqual
+		}
+	}
+
+	value, diags := rhs.Value(nil)
+	if diags.HasErrors() || value.Type() != cty.Number || value.IsNull() {
+		return nil, false
+	}
+	bf := value.AsBigFloat()
+	number, _ := bf.Float64()
+
+	if isTerraformVariableReference(lhs, v.Name) {
+		switch op {
+		case hclsyntax.OpGreaterThan:
+			return map[string]any{"exclusiveMinimum": number}, true
+		case hclsyntax.OpGreaterThanOrEqual:
+			return map[string]any{"minimum": number}, true
+		case hclsyntax.OpLessThan:
+			return map[string]any{"exclusiveMaximum": number}, true
+		case hclsyntax.OpLessThanOrEqual:
+			return map[string]any{"maximum": number}, true
+		}
+		return nil, false
+	}
+
+	// `length(var.x)` - the keywords depend on the type of the variable
+	call, ok := lhs.(*hclsyntax.FunctionCallExpr)
+	if !ok || call.Name != "length" || len(call.Args) != 1 || !isTerraformVariableReference(call.Args[0], v.Name) || !bf.IsInt() {
+		return nil, false
+	}
+
+	var minKeyword, maxKeyword string
+	switch {
+	case v.Type == cty.String:
+		minKeyword, maxKeyword = "minLength", "maxLength"
+	case v.Type.IsListType(), v.Type.IsSetType(), v.Type.IsTupleType():
+		minKeyword, maxKeyword = "minItems", "maxItems"
+	case v.Type.IsMapType(), v.Type.IsObjectType():
+		minKeyword, maxKeyword = "minProperties", "maxProperties"
+	default:
+		return nil, false
+	}
+
+	length, _ := bf.Int64()
+
+	switch op {
+	case hclsyntax.OpGreaterThan:
+		return map[string]any{minKeyword: length + 1}, true
+	case hclsyntax.OpGreaterThanOrEqual:
+		return map[string]any{minKeyword: length}, true
+	case hclsyntax.OpLessThan:
+		if length < 1 {
+			return nil, false
+		}
+		return map[string]any{maxKeyword: length - 1}, true
+	case hclsyntax.OpLessThanOrEqual:
+		return map[string]any{maxKeyword: length}, true
+	case hclsyntax.OpEqual:
+		return map[string]any{minKeyword: length, maxKeyword: length}, true
+	}
+
+	return nil, false
+}
+
+// isTerraformVariableReference checks if the expression is a reference to the variable (`var.<name>`)
+func isTerraformVariableReference(expr hclsyntax.Expression, name string) bool {
+	e, ok := expr.(*hclsyntax.ScopeTraversalExpr)
+	if !ok || len(e.Traversal) != 2 || e.Traversal.RootName() != "var" {
+		return false
+	}
+	attr, ok := e.Traversal[1].(hcl.TraverseAttr)
+	return ok && attr.Name == name
+}
+
+// isLiteralValue checks if the expression is the literal value (`null`, `true`, `false`)
+func isLiteralValue(expr hclsyntax.Expression, value cty.Value) bool {
+	e, ok := expr.(*hclsyntax.LiteralValueExpr)
+	if !ok {
+		return false
+	}
+	if value.IsNull() {
+		return e.Val.IsNull()
+	}
+	return !e.Val.IsNull() && e.Val.Type() == value.Type() && e.Val.Equals(value).True()
+}
+
+// validateWithGeneratedJsonSchema validates the data using the JSON Schema, and returns a message for each failed check
+// in the format `<path>: <message>`
+func validateWithGeneratedJsonSchema(data any, schemaName string, schemaMap map[string]any) ([]string, error) {
+	schemaText, err := u.ConvertToJSONFast(schemaMap)
+	if err != nil {
+		return nil, err
+	}
+
+	dataJson, err := u.ConvertToJSONFast(data)
+	if err != nil {
+		return nil, err
+	}
+
+	dataFromJson, err := u.ConvertFromJSON(dataJson)
+	if err != nil {
+		return nil, err
+	}
+
+	compiler := jsonschema.NewCompiler()
+	compiler.Draft = jsonschema.Draft2020
+	if err = compiler.AddResource(schemaName, strings.NewReader(schemaText)); err != nil {
+		return nil, err
+	}
+
+	s, err := compiler.Compile(schemaName)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.Validate(dataFromJson)
+	if err == nil {
+		return nil, nil
+	}
+
+	validationError, ok := err.(*jsonschema.ValidationError)
+	if !ok {
+		return nil, err
+	}
+
+	var messages []string
+	collectJsonSchemaValidationMessages(validationError, &messages)
+	return u.UniqueStrings(messages), nil
+}
+
+// collectJsonSchemaValidationMessages collects the messages of the leaf JSON Schema validation errors
+func collectJsonSchemaValidationMessages(e *jsonschema.ValidationError, messages *[]string) {
+	if len(e.Causes) == 0 {
+		location := strings.ReplaceAll(strings.TrimPrefix(e.InstanceLocation, "/"), "/", ".")
+		if location == "" {
+			*messages = append(*messages, e.Message)
+		} else {
+			*messages = append(*messages, fmt.Sprintf("%s: %s", location, e.Message))
+		}
+		return
+	}
+	for _, c := range e.Causes {
+		collectJsonSchemaValidationMessages(c, messages)
+	}
+}
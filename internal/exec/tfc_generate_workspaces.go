@@ -0,0 +1,306 @@
+package exec
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	cfg "github.com/cloudposse/atmos/pkg/config"
+	c "github.com/cloudposse/atmos/pkg/convert"
+	"github.com/cloudposse/atmos/pkg/schema"
+	u "github.com/cloudposse/atmos/pkg/utils"
+)
+
+// tfcWorkspaceAttributes are the `settings.tfc` attributes that are passed as is to the `tfe_workspace` Terraform resource
+var tfcWorkspaceAttributes = []string{
+	"agent_pool_id",
+	"allow_destroy_plan",
+	"assessments_enabled",
+	"auto_apply",
+	"auto_apply_run_trigger",
+	"description",
+	"execution_mode",
+	"file_triggers_enabled",
+	"force_delete",
+	"global_remote_state",
+	"project_id",
+	"queue_all_runs",
+	"remote_state_consumer_ids",
+	"speculative_enabled",
+	"ssh_key_id",
+	"structured_run_output_enabled",
+	"tag_names",
+	"terraform_version",
+	"trigger_patterns",
+	"trigger_prefixes",
+	"vcs_repo",
+}
+
+// tfcWorkspace is the TFC workspace of a terraform component in a stack
+type tfcWorkspace struct {
+	name         string
+	resourceName string
+	resource     map[string]any
+	vars         map[string]any
+	env          map[string]any
+	dependsOn    []string
+}
+
+// ExecuteTfcGenerateWorkspacesCmd executes `tfc generate workspaces` command
+func ExecuteTfcGenerateWorkspacesCmd(cmd *cobra.Command, args []string) error {
+	info, err := processCommandLineArgs("", cmd, args, nil)
+	if err != nil {
+		return err
+	}
+
+	cliConfig, err := cfg.InitCliConfig(info, true)
+	if err != nil {
+		return err
+	}
+
+	flags := cmd.Flags()
+
+	file, err := flags.GetString("file")
+	if err != nil {
+		return err
+	}
+
+	organization, err := flags.GetString("organization")
+	if err != nil {
+		return err
+	}
+
+	terraformJson, err := ExecuteTfcGenerateWorkspaces(cliConfig, organization)
+	if err != nil {
+		return err
+	}
+
+	return printOrWriteToFile("json", file, terraformJson)
+}
+
+// ExecuteTfcGenerateWorkspaces generates the Terraform/OpenTofu JSON configuration with the `tfe_workspace`, `tfe_variable` and `tfe_run_trigger`
+// resources for the terraform components with `settings.tfc.workspace_enabled: true` in all stacks.
+// The `organization` is used for the workspaces that don't specify `settings.tfc.organization`
+func ExecuteTfcGenerateWorkspaces(cliConfig schema.CliConfiguration, organization string) (map[string]any, error) {
+	stacksMap, _, err := FindStacksMap(cliConfig, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// The TFC workspaces keyed by `<stack>/<component>`
+	workspaces := map[string]*tfcWorkspace{}
+	// The component and stack of each TFC workspace name, to detect the duplicate names
+	workspaceNames := map[string]string{}
+	// The TFC workspace name of each Terraform resource name, to detect the names that are converted to the same resource name
+	resourceNames := map[string]string{}
+
+	for _, stackConfigFileName := range u.StringKeysFromMap(stacksMap) {
+		componentsSection, ok := stacksMap[stackConfigFileName].(map[any]any)["components"].(map[string]any)
+		if !ok {
+			continue
+		}
+
+		terraformSection, ok := componentsSection["terraform"].(map[string]any)
+		if !ok {
+			continue
+		}
+
+		for _, componentName := range u.StringKeysFromMap(terraformSection) {
+			componentSection, ok := terraformSection[componentName].(map[string]any)
+			if !ok {
+				continue
+			}
+
+			settingsSection, _ := componentSection["settings"].(map[any]any)
+			tfcSettings, _ := settingsSection["tfc"].(map[any]any)
+
+			// If the TFC workspace is disabled, don't include it
+			if workspaceEnabled, ok := tfcSettings["workspace_enabled"].(bool); !ok || !workspaceEnabled {
+				continue
+			}
+
+			varsSection, _ := componentSection["vars"].(map[any]any)
+			envSection, _ := componentSection["env"].(map[any]any)
+
+			// Don't include abstract and disabled components
+			metadataSection, baseComponentName, componentIsAbstract := ProcessComponentMetadata(componentName, componentSection)
+			if componentIsAbstract || !IsComponentEnabled(metadataSection, varsSection) {
+				continue
+			}
+
+			context := cfg.GetContextFromVars(varsSection)
+			context.Component = componentName
+			context.BaseComponent = baseComponentName
+
+			stackName, err := componentStackName(cliConfig, stackConfigFileName, context)
+			if err != nil {
+				return nil, err
+			}
+
+			workspaceName, workspaceNamePattern := BuildTfcWorkspaceName(tfcSettings, context, stackName)
+
+			if other, ok := workspaceNames[workspaceName]; ok {
+				return nil, fmt.Errorf("duplicate TFC workspace name '%s' for the component '%s' in the stack '%s' and the component %s. "+
+					"Check if the TFC workspace name pattern 'workspace_name_pattern=%s' is specific enough",
+					workspaceName, componentName, stackName, other, workspaceNamePattern)
+			}
+			workspaceNames[workspaceName] = fmt.Sprintf("'%s' in the stack '%s'", componentName, stackName)
+
+			resourceName := terraformResourceName(workspaceName)
+			if other, ok := resourceNames[resourceName]; ok {
+				return nil, fmt.Errorf("the TFC workspaces '%s' and '%s' have the same Terraform resource name '%s'. "+
+					"Check the 'workspace_name' and 'workspace_name_pattern' attributes in the 'settings.tfc' section",
+					other, workspaceName, resourceName)
+			}
+			resourceNames[resourceName] = workspaceName
+
+			// Terraform component folder (relative to the repository root)
+			terraformComponent := componentName
+			if baseComponentName != "" {
+				terraformComponent = baseComponentName
+			}
+			workingDirectory := path.Join(cliConfig.Components.Terraform.BasePath, terraformComponent)
+			if v, ok := tfcSettings["working_directory"].(string); ok && v != "" {
+				workingDirectory = v
+			}
+
+			workspaceOrganization := organization
+			if v, ok := tfcSettings["organization"].(string); ok && v != "" {
+				workspaceOrganization = v
+			}
+
+			resource := map[string]any{
+				"name":              workspaceName,
+				"working_directory": workingDirectory,
+			}
+			if workspaceOrganization != "" {
+				resource["organization"] = workspaceOrganization
+			}
+
+			for _, attribute := range tfcWorkspaceAttributes {
+				if v, ok := tfcSettings[attribute]; ok && v != nil {
+					resource[attribute] = v
+				}
+			}
+
+			dependsOn, err := findSettingsDependsOnComponents(cliConfig, stackConfigFileName, context, settingsSection)
+			if err != nil {
+				return nil, err
+			}
+
+			workspaces[stackName+"/"+componentName] = &tfcWorkspace{
+				name:         workspaceName,
+				resourceName: resourceName,
+				resource:     resource,
+				vars:         c.MapsOfInterfacesToMapsOfStrings(varsSection),
+				env:          c.MapsOfInterfacesToMapsOfStrings(envSection),
+				dependsOn:    dependsOn,
+			}
+		}
+	}
+
+	workspaceResources := map[string]any{}
+	variableResources := map[string]any{}
+	runTriggerResources := map[string]any{}
+
+	for _, workspace := range workspaces {
+		workspaceResources[workspace.resourceName] = workspace.resource
+		workspaceId := fmt.Sprintf("${tfe_workspace.%s.id}", workspace.resourceName)
+
+		// Terraform variables. The values that are not strings are HCL-encoded
+		for _, key := range u.StringKeysFromMap(workspace.vars) {
+			variable := map[string]any{
+				"key":          key,
+				"category":     "terraform",
+				"workspace_id": workspaceId,
+			}
+
+			if v, ok := workspace.vars[key].(string); ok {
+				variable["value"] = escapeTerraformJsonString(v)
+				variable["hcl"] = false
+			} else {
+				v, err := u.ConvertToHclExpression(workspace.vars[key])
+				if err != nil {
+					return nil, err
+				}
+				variable["value"] = escapeTerraformJsonString(v)
+				variable["hcl"] = true
+			}
+
+			variableResources[terraformResourceName(workspace.resourceName+"_var_"+key)] = variable
+		}
+
+		// ENV variables
+		for _, key := range u.StringKeysFromMap(workspace.env) {
+			value := workspace.env[key]
+			if value == nil {
+				continue
+			}
+
+			variableResources[terraformResourceName(workspace.resourceName+"_env_"+key)] = map[string]any{
+				"key":          key,
+				"value":        escapeTerraformJsonString(fmt.Sprintf("%v", value)),
+				"category":     "env",
+				"workspace_id": workspaceId,
+			}
+		}
+
+		// Run triggers from the workspaces of the components in the `settings.depends_on` section.
+		// The dependencies without TFC workspaces (e.g. `settings.tfc.workspace_enabled` is `false`) are skipped
+		for _, dependsOn := range workspace.dependsOn {
+			dependency, ok := workspaces[dependsOn]
+			if !ok || dependency == workspace {
+				continue
+			}
+
+			runTriggerResources[workspace.resourceName+"__"+dependency.resourceName] = map[string]any{
+				"workspace_id":  workspaceId,
+				"sourceable_id": fmt.Sprintf("${tfe_workspace.%s.id}", dependency.resourceName),
+			}
+		}
+	}
+
+	resources := map[string]any{
+		"tfe_workspace": workspaceResources,
+	}
+	if len(variableResources) > 0 {
+		resources["tfe_variable"] = variableResources
+	}
+	if len(runTriggerResources) > 0 {
+		resources["tfe_run_trigger"] = runTriggerResources
+	}
+
+	result := map[string]any{
+		"terraform": map[string]any{
+			"required_providers": map[string]any{
+				"tfe": map[string]any{
+					"source": "hashicorp/tfe",
+				},
+			},
+		},
+		"resource": resources,
+	}
+
+	return result, nil
+}
+
+// BuildTfcWorkspaceName builds the TFC workspace name from the `settings.tfc.workspace_name_pattern` or `settings.tfc.workspace_name`.
+// By default, the TFC workspace name is `<stack>-<component>`. It returns the workspace name and the pattern used to build it
+func BuildTfcWorkspaceName(tfcSettings map[any]any, context schema.Context, stackName string) (string, string) {
+	if workspaceNamePattern, ok := tfcSettings["workspace_name_pattern"].(string); ok && workspaceNamePattern != "" {
+		return strings.Replace(cfg.ReplaceContextTokens(context, workspaceNamePattern), "/", "-", -1), workspaceNamePattern
+	}
+	if workspaceName, ok := tfcSettings["workspace_name"].(string); ok && workspaceName != "" {
+		return workspaceName, workspaceName
+	}
+	return strings.Replace(fmt.Sprintf("%s-%s", stackName, context.Component), "/", "-", -1), stackName + "-{component}"
+}
+
+// escapeTerraformJsonString escapes the Terraform template sequences (`${` and `%{`) in the string,
+// so it's used as is in the Terraform JSON configuration
+func escapeTerraformJsonString(s string) string {
+	s = strings.Replace(s, "${", "$${", -1)
+	return strings.Replace(s, "%{", "%%{", -1)
+}
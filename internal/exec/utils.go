@@ -27,6 +27,7 @@ var (
 		cfg.TerraformDirFlag,
 		cfg.HelmfileDirFlag,
 		cfg.CliConfigDirFlag,
+		cfg.CliConfigFileFlag,
 		cfg.StackDirFlag,
 		cfg.BasePathFlag,
 		cfg.GlobalOptionsFlag,
@@ -144,6 +145,7 @@ func FindComponentConfig(
 	configAndStacksInfo.Command = command
 	configAndStacksInfo.ComponentInheritanceChain = componentInheritanceChain
 	configAndStacksInfo.ComponentIsAbstract = componentIsAbstract
+	configAndStacksInfo.ComponentIsEnabled = IsComponentEnabled(componentMetadata, componentVarsSection)
 	configAndStacksInfo.ComponentMetadataSection = componentMetadata
 	configAndStacksInfo.ComponentImportsSection = componentImportsSection
 
@@ -195,12 +197,14 @@ func processCommandLineArgs(
 	configAndStacksInfo.PlanFile = argsAndFlagsInfo.PlanFile
 	configAndStacksInfo.DryRun = argsAndFlagsInfo.DryRun
 	configAndStacksInfo.SkipInit = argsAndFlagsInfo.SkipInit
+	configAndStacksInfo.NoValidationCache = argsAndFlagsInfo.NoValidationCache
 	configAndStacksInfo.NeedHelp = argsAndFlagsInfo.NeedHelp
 	configAndStacksInfo.JsonSchemaDir = argsAndFlagsInfo.JsonSchemaDir
 	configAndStacksInfo.AtmosManifestJsonSchema = argsAndFlagsInfo.AtmosManifestJsonSchema
 	configAndStacksInfo.OpaDir = argsAndFlagsInfo.OpaDir
 	configAndStacksInfo.CueDir = argsAndFlagsInfo.CueDir
 	configAndStacksInfo.RedirectStdErr = argsAndFlagsInfo.RedirectStdErr
+	configAndStacksInfo.CliConfigFile = argsAndFlagsInfo.CliConfigFile
 
 	// Check if `-h` or `--help` flags are specified
 	if argsAndFlagsInfo.NeedHelp {
@@ -526,6 +530,16 @@ func ProcessStacks(
 
 	configAndStacksInfo.ComponentSection["sources"] = sources
 
+	// `provenance` (the component in the inheritance chain, and the stack manifest file and line where the final values are set)
+	if configAndStacksInfo.Provenance {
+		provenance, err := ProcessConfigProvenance(cliConfig, sources)
+		if err != nil {
+			return configAndStacksInfo, err
+		}
+
+		configAndStacksInfo.ComponentSection["provenance"] = provenance
+	}
+
 	// Component dependencies
 	componentDeps, componentDepsAll, err := FindComponentDependencies(configAndStacksInfo.StackFile, sources)
 	if err != nil {
@@ -594,6 +608,20 @@ func processArgsAndFlags(componentType string, inputArgsAndFlags []string) (sche
 			info.ConfigDir = configDirFlagParts[1]
 		}
 
+		// `--config` is a prefix of `--config-dir`, so the flag with the value is matched with the `=` separator
+		if arg == cfg.CliConfigFileFlag {
+			if len(inputArgsAndFlags) <= (i + 1) {
+				return info, fmt.Errorf("invalid flag: %s", arg)
+			}
+			info.CliConfigFile = inputArgsAndFlags[i+1]
+		} else if strings.HasPrefix(arg, cfg.CliConfigFileFlag+"=") {
+			var configFileFlagParts = strings.Split(arg, "=")
+			if len(configFileFlagParts) != 2 {
+				return info, fmt.Errorf("invalid flag: %s", arg)
+			}
+			info.CliConfigFile = configFileFlagParts[1]
+		}
+
 		if arg == cfg.StackDirFlag {
 			if len(inputArgsAndFlags) <= (i + 1) {
 				return info, fmt.Errorf("invalid flag: %s", arg)
@@ -764,6 +792,12 @@ func processArgsAndFlags(componentType string, inputArgsAndFlags []string) (sche
 			info.SkipInit = true
 		}
 
+		// `--no-validation-cache` is a boolean flag without a value, only the flag itself is removed from the arg list
+		if arg == cfg.NoValidationCacheFlag {
+			info.NoValidationCache = true
+			indexesToRemove = append(indexesToRemove, i)
+		}
+
 		if arg == cfg.HelpFlag1 || arg == cfg.HelpFlag2 {
 			info.NeedHelp = true
 		}
@@ -58,6 +58,15 @@ func ExecuteValidateComponentCmd(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	noValidationCache, err := flags.GetBool("no-validation-cache")
+	if err != nil {
+		return err
+	}
+
+	if noValidationCache {
+		cliConfig.Validate.Cache.Disabled = true
+	}
+
 	_, err = ExecuteValidateComponent(cliConfig, info, componentName, stack, schemaPath, schemaType, modulePaths, timeout)
 	if err != nil {
 		return err
@@ -77,6 +86,21 @@ func ExecuteValidateComponent(
 	modulePaths []string,
 	timeoutSeconds int,
 ) (bool, error) {
+	componentSection, err := findComponentSectionInStack(cliConfig, configAndStacksInfo, componentName, stack)
+	if err != nil {
+		return false, err
+	}
+
+	return ValidateComponent(cliConfig, componentName, componentSection, schemaPath, schemaType, modulePaths, timeoutSeconds)
+}
+
+// findComponentSectionInStack processes the stacks and returns the config of the Terraform or Helmfile component in the stack
+func findComponentSectionInStack(
+	cliConfig schema.CliConfiguration,
+	configAndStacksInfo schema.ConfigAndStacksInfo,
+	componentName string,
+	stack string,
+) (map[string]any, error) {
 	configAndStacksInfo.ComponentFromArg = componentName
 	configAndStacksInfo.Stack = stack
 
@@ -86,13 +110,11 @@ func ExecuteValidateComponent(
 		configAndStacksInfo.ComponentType = "helmfile"
 		configAndStacksInfo, err = ProcessStacks(cliConfig, configAndStacksInfo, true)
 		if err != nil {
-			return false, err
+			return nil, err
 		}
 	}
 
-	componentSection := configAndStacksInfo.ComponentSection
-
-	return ValidateComponent(cliConfig, componentName, componentSection, schemaPath, schemaType, modulePaths, timeoutSeconds)
+	return configAndStacksInfo.ComponentSection, nil
 }
 
 // ValidateComponent validates the component config using JsonSchema, OPA or CUE schema documents
@@ -111,7 +133,9 @@ func ValidateComponent(
 	if schemaPath != "" && schemaType != "" {
 		u.LogDebug(cliConfig, fmt.Sprintf("\nValidating the component '%s' using '%s' file '%s'", componentName, schemaType, schemaPath))
 
-		ok, err = validateComponentInternal(cliConfig, componentSection, schemaPath, schemaType, modulePaths, timeoutSeconds)
+		var result schema.PolicyResult
+		ok, result, err = validateComponentWithCache(cliConfig, componentSection, schemaPath, schemaType, modulePaths, timeoutSeconds, "")
+		logPolicyWarnings(cliConfig, componentName, result.Warnings)
 		if err != nil {
 			return false, err
 		}
@@ -162,7 +186,8 @@ func ValidateComponent(
 				u.LogDebug(cliConfig, v.Description)
 			}
 
-			ok2, err := validateComponentInternal(cliConfig, componentSection, finalSchemaPath, finalSchemaType, finalModulePaths, finalTimeoutSeconds)
+			ok2, result, err := validateComponentWithCache(cliConfig, componentSection, finalSchemaPath, finalSchemaType, finalModulePaths, finalTimeoutSeconds, v.FailOnSeverity)
+			logPolicyWarnings(cliConfig, componentName, result.Warnings)
 			if err != nil {
 				return false, err
 			}
@@ -175,6 +200,8 @@ func ValidateComponent(
 	return ok, nil
 }
 
+// validateComponentInternal validates the component config using the schema document.
+// For OPA policies, it also returns the structured errors and warnings reported by the policy
 func validateComponentInternal(
 	cliConfig schema.CliConfiguration,
 	componentSection any,
@@ -182,40 +209,22 @@ func validateComponentInternal(
 	schemaType string,
 	modulePaths []string,
 	timeoutSeconds int,
-) (bool, error) {
+	failOnSeverity string,
+) (bool, schema.PolicyResult, error) {
+	var result schema.PolicyResult
+
 	if schemaType != "jsonschema" && schemaType != "opa" && schemaType != "cue" {
-		return false, fmt.Errorf("invalid schema type '%s'. Supported types: jsonschema, opa, cue", schemaType)
+		return false, result, fmt.Errorf("invalid schema type '%s'. Supported types: jsonschema, opa, cue", schemaType)
 	}
 
-	// Check if the file pointed to by 'schemaPath' exists.
-	// If not, join it with the schemas `base_path` from the CLI config
-	var filePath string
-	if u.FileExists(schemaPath) {
-		filePath = schemaPath
-	} else {
-		switch schemaType {
-		case "jsonschema":
-			{
-				filePath = path.Join(cliConfig.BasePath, cliConfig.Schemas.JsonSchema.BasePath, schemaPath)
-			}
-		case "opa":
-			{
-				filePath = path.Join(cliConfig.BasePath, cliConfig.Schemas.Opa.BasePath, schemaPath)
-			}
-		case "cue":
-			{
-				filePath = path.Join(cliConfig.BasePath, cliConfig.Schemas.Cue.BasePath, schemaPath)
-			}
-		}
-
-		if !u.FileExists(filePath) {
-			return false, fmt.Errorf("the file '%s' does not exist for schema type '%s'", schemaPath, schemaType)
-		}
+	filePath, err := findValidationSchemaFile(cliConfig, schemaPath, schemaType)
+	if err != nil {
+		return false, result, err
 	}
 
 	fileContent, err := os.ReadFile(filePath)
 	if err != nil {
-		return false, err
+		return false, result, err
 	}
 
 	schemaText := string(fileContent)
@@ -226,38 +235,82 @@ func validateComponentInternal(
 		{
 			ok, err = ValidateWithJsonSchema(componentSection, filePath, schemaText)
 			if err != nil {
-				return false, err
+				return false, result, err
 			}
 		}
 	case "opa":
 		{
 			modulePathsAbsolute, err := u.JoinAbsolutePathWithPaths(path.Join(cliConfig.BasePath, cliConfig.Schemas.Opa.BasePath), modulePaths)
 			if err != nil {
-				return false, err
+				return false, result, err
 			}
 
-			ok, err = ValidateWithOpa(componentSection, filePath, modulePathsAbsolute, timeoutSeconds)
+			result, err = EvaluateOpaPolicy(componentSection, filePath, modulePathsAbsolute, timeoutSeconds)
 			if err != nil {
-				return false, err
+				return false, result, err
+			}
+
+			result, err = applyPolicySeverityThreshold(result, failOnSeverity)
+			if err != nil {
+				return false, result, err
 			}
+
+			locatePolicyViolations(cliConfig, componentSection, result.Errors)
+			locatePolicyViolations(cliConfig, componentSection, result.Warnings)
+
+			if len(result.Errors) > 0 {
+				return false, result, errors.New(formatPolicyViolations(result.Errors))
+			}
+			ok = true
 		}
 	case "opa_legacy":
 		{
 			ok, err = ValidateWithOpaLegacy(componentSection, filePath, schemaText, timeoutSeconds)
 			if err != nil {
-				return false, err
+				return false, result, err
 			}
 		}
 	case "cue":
 		{
-			ok, err = ValidateWithCue(componentSection, filePath, schemaText)
+			ok, err = ValidateWithCue(componentSection, filePath, path.Join(cliConfig.BasePath, cliConfig.Schemas.Cue.BasePath))
 			if err != nil {
-				return false, err
+				return false, result, err
 			}
 		}
 	}
 
-	return ok, nil
+	return ok, result, nil
+}
+
+// findValidationSchemaFile returns the path to the schema file.
+// If the file pointed to by 'schemaPath' does not exist, the path is joined with the schemas `base_path` from the CLI config
+func findValidationSchemaFile(cliConfig schema.CliConfiguration, schemaPath string, schemaType string) (string, error) {
+	if u.FileExists(schemaPath) {
+		return schemaPath, nil
+	}
+
+	var filePath string
+	switch schemaType {
+	case "jsonschema":
+		filePath = path.Join(cliConfig.BasePath, cliConfig.Schemas.JsonSchema.BasePath, schemaPath)
+	case "opa":
+		filePath = path.Join(cliConfig.BasePath, cliConfig.Schemas.Opa.BasePath, schemaPath)
+	case "cue":
+		filePath = path.Join(cliConfig.BasePath, cliConfig.Schemas.Cue.BasePath, schemaPath)
+	}
+
+	if !u.FileExists(filePath) {
+		return "", fmt.Errorf("the file '%s' does not exist for schema type '%s'", schemaPath, schemaType)
+	}
+
+	return filePath, nil
+}
+
+// logPolicyWarnings logs the warnings reported by the OPA policies
+func logPolicyWarnings(cliConfig schema.CliConfiguration, componentName string, warnings []schema.PolicyViolation) {
+	for _, w := range warnings {
+		u.LogWarning(cliConfig, fmt.Sprintf("component '%s': %s", componentName, formatPolicyViolation(w)))
+	}
 }
 
 // FindValidationSection finds 'validation' section in the component config
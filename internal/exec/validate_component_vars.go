@@ -0,0 +1,200 @@
+package exec
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/cloudposse/atmos/pkg/schema"
+	u "github.com/cloudposse/atmos/pkg/utils"
+)
+
+// terraformComponentInStack is an Atmos terraform component in a stack and the Terraform component it points to
+type terraformComponentInStack struct {
+	stack              string
+	component          string
+	terraformComponent string
+	componentPath      string
+	componentSection   map[string]any
+}
+
+// findTerraformComponentsInStacks finds all the real and enabled terraform components in all stacks.
+// The components without the Terraform code in the components folder (e.g. not vendored yet) are skipped
+func findTerraformComponentsInStacks(cliConfig schema.CliConfiguration) ([]terraformComponentInStack, error) {
+	stacksMap, err := ExecuteDescribeStacks(cliConfig, "", nil, []string{"terraform"}, nil, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []terraformComponentInStack
+
+	for _, stackName := range u.StringKeysFromMap(stacksMap) {
+		stackSection, ok := stacksMap[stackName].(map[string]any)
+		if !ok {
+			continue
+		}
+
+		componentsSection, ok := stackSection["components"].(map[string]any)
+		if !ok {
+			continue
+		}
+
+		terraformSection, ok := componentsSection["terraform"].(map[string]any)
+		if !ok {
+			continue
+		}
+
+		for _, componentName := range u.StringKeysFromMap(terraformSection) {
+			componentSection, ok := terraformSection[componentName].(map[string]any)
+			if !ok {
+				continue
+			}
+
+			componentMetadata, _, componentIsAbstract := ProcessComponentMetadata(componentName, componentSection)
+			if componentIsAbstract {
+				continue
+			}
+			varsSection, _ := componentSection["vars"].(map[any]any)
+			if !IsComponentEnabled(componentMetadata, varsSection) {
+				continue
+			}
+
+			terraformComponent, ok := componentSection["component"].(string)
+			if !ok || terraformComponent == "" {
+				terraformComponent = componentName
+			}
+
+			componentPath := path.Join(cliConfig.TerraformDirAbsolutePath, terraformComponent)
+
+			if !u.FileOrDirExists(componentPath) {
+				u.LogDebug(cliConfig, fmt.Sprintf("Skipping the component '%s' in the stack '%s': the folder '%s' does not exist",
+					componentName, stackName, componentPath))
+				continue
+			}
+
+			result = append(result, terraformComponentInStack{
+				stack:              stackName,
+				component:          componentName,
+				terraformComponent: terraformComponent,
+				componentPath:      componentPath,
+				componentSection:   componentSection,
+			})
+		}
+	}
+
+	return result, nil
+}
+
+// ValidateComponentVarsWithGeneratedSchemas validates the `vars` section of all the real and enabled terraform components in all stacks
+// using the JSON Schemas generated from the Terraform variables of the components
+func ValidateComponentVarsWithGeneratedSchemas(cliConfig schema.CliConfiguration) ([]string, error) {
+	components, err := findTerraformComponentsInStacks(cliConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	// The schemas are generated once for each Terraform component
+	schemas := map[string]map[string]any{}
+	var errorMessages []string
+
+	for _, c := range components {
+		jsonSchema, ok := schemas[c.terraformComponent]
+		if !ok {
+			jsonSchema, err = ExecuteTerraformGenerateSchema(c.terraformComponent, c.componentPath)
+			if err != nil {
+				return nil, err
+			}
+			schemas[c.terraformComponent] = jsonSchema
+		}
+
+		messages, err := validateWithGeneratedJsonSchema(map[string]any{"vars": c.componentSection["vars"]}, c.terraformComponent, jsonSchema)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(messages) > 0 {
+			errorMessages = append(errorMessages, fmt.Sprintf("the 'vars' section of the component '%s' in the stack '%s' "+
+				"does not match the variables of the Terraform component '%s':\n  %s",
+				c.component, c.stack, c.terraformComponent, strings.Join(messages, "\n  ")))
+		}
+	}
+
+	return errorMessages, nil
+}
+
+// CheckComponentVars checks the `vars` section of all the real and enabled terraform components in all stacks against the variables
+// declared in the Terraform components, and reports the undeclared variables (e.g. misspelled) and the required variables without values
+func CheckComponentVars(cliConfig schema.CliConfiguration) ([]string, error) {
+	components, err := findTerraformComponentsInStacks(cliConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	// The variables are loaded once for each Terraform component
+	variablesMap := map[string][]terraformVariable{}
+	var errorMessages []string
+
+	for _, c := range components {
+		variables, ok := variablesMap[c.terraformComponent]
+		if !ok {
+			variables, err = loadTerraformVariables(c.componentPath)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read the variables of the Terraform component '%s': %w", c.terraformComponent, err)
+			}
+			variablesMap[c.terraformComponent] = variables
+		}
+
+		varsSection, _ := c.componentSection["vars"].(map[any]any)
+
+		messages := checkComponentVars(varsSection, variables)
+
+		if len(messages) > 0 {
+			errorMessages = append(errorMessages, fmt.Sprintf("the component '%s' in the stack '%s' (Terraform component '%s'):\n  %s",
+				c.component, c.stack, c.terraformComponent, strings.Join(messages, "\n  ")))
+		}
+	}
+
+	return errorMessages, nil
+}
+
+// checkComponentVars returns a message for each undeclared variable in the `vars` section (with a "did you mean" suggestion),
+// and for each required variable (without a default value) that is not set or is `null`
+func checkComponentVars(varsSection map[any]any, variables []terraformVariable) []string {
+	var messages []string
+
+	declared := make([]string, 0, len(variables))
+	for _, v := range variables {
+		declared = append(declared, v.Name)
+	}
+
+	var names []string
+	for k := range varsSection {
+		names = append(names, fmt.Sprintf("%v", k))
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		if u.SliceContainsString(declared, name) {
+			continue
+		}
+
+		message := fmt.Sprintf("undeclared variable '%s'", name)
+		if suggestion := u.FindClosestString(name, declared, 2); suggestion != "" {
+			message += fmt.Sprintf(", did you mean '%s'?", suggestion)
+		}
+		messages = append(messages, message)
+	}
+
+	for _, v := range variables {
+		if v.HasDefault {
+			continue
+		}
+		if value, ok := varsSection[v.Name]; !ok || value == nil {
+			messages = append(messages, fmt.Sprintf("required variable '%s' has no value", v.Name))
+		}
+	}
+
+	return messages
+}
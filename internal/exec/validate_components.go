@@ -0,0 +1,315 @@
+package exec
+
+import (
+	"fmt"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	cfg "github.com/cloudposse/atmos/pkg/config"
+	"github.com/cloudposse/atmos/pkg/schema"
+	u "github.com/cloudposse/atmos/pkg/utils"
+)
+
+// componentValidationJob is a validation of a component in a stack using one of the policies from the `settings.validation` section
+type componentValidationJob struct {
+	result           schema.ComponentValidationResult
+	componentSection map[string]any
+	modulePaths      []string
+	timeout          int
+	failOnSeverity   string
+}
+
+// ExecuteValidateComponentsCmd executes `validate components` command
+func ExecuteValidateComponentsCmd(cmd *cobra.Command, args []string) error {
+	info, err := processCommandLineArgs("", cmd, args, nil)
+	if err != nil {
+		return err
+	}
+
+	cliConfig, err := cfg.InitCliConfig(info, true)
+	if err != nil {
+		return err
+	}
+
+	flags := cmd.Flags()
+
+	stacksCsv, err := flags.GetString("stacks")
+	if err != nil {
+		return err
+	}
+	var stacks []string
+	if stacksCsv != "" {
+		stacks = strings.Split(stacksCsv, ",")
+	}
+
+	componentsCsv, err := flags.GetString("components")
+	if err != nil {
+		return err
+	}
+	var components []string
+	if componentsCsv != "" {
+		components = strings.Split(componentsCsv, ",")
+	}
+
+	format, err := flags.GetString("format")
+	if err != nil {
+		return err
+	}
+
+	if format == "" {
+		format = "text"
+	}
+
+	if format != "text" && format != "junit" && format != "sarif" {
+		return fmt.Errorf("invalid '--format' flag '%s'. Valid values are 'text' (default), 'junit' and 'sarif'", format)
+	}
+
+	file, err := flags.GetString("file")
+	if err != nil {
+		return err
+	}
+
+	parallelism, err := flags.GetInt("parallelism")
+	if err != nil {
+		return err
+	}
+
+	noValidationCache, err := flags.GetBool("no-validation-cache")
+	if err != nil {
+		return err
+	}
+
+	if noValidationCache {
+		cliConfig.Validate.Cache.Disabled = true
+	}
+
+	results, err := ExecuteValidateComponents(cliConfig, stacks, components, parallelism)
+	if err != nil {
+		return err
+	}
+
+	err = WriteComponentValidationReport(cliConfig, results, format, file)
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, r := range results {
+		if !r.Passed {
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d component validations failed", failed, len(results))
+	}
+
+	return nil
+}
+
+// ExecuteValidateComponents validates all components in all stacks using the policies from the components' `settings.validation` sections.
+// The validations are executed in parallel, and the results are sorted by policy, component and stack
+func ExecuteValidateComponents(
+	cliConfig schema.CliConfiguration,
+	stacks []string,
+	components []string,
+	parallelism int,
+) ([]schema.ComponentValidationResult, error) {
+	stacksMap, rawStackConfigs, err := executeDescribeStacks(cliConfig, "", components, nil, nil, false)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs, err := findComponentValidationJobs(stacksMap, rawStackConfigs, stacks, components)
+	if err != nil {
+		return nil, err
+	}
+
+	if parallelism <= 0 {
+		parallelism = runtime.NumCPU()
+	}
+
+	results := make([]schema.ComponentValidationResult, len(jobs))
+	jobIndexes := make(chan int)
+	wg := sync.WaitGroup{}
+
+	for w := 0; w < parallelism; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobIndexes {
+				results[i] = runComponentValidationJob(cliConfig, jobs[i])
+			}
+		}()
+	}
+
+	for i := range jobs {
+		jobIndexes <- i
+	}
+	close(jobIndexes)
+	wg.Wait()
+
+	return results, nil
+}
+
+// findComponentValidationJobs finds the enabled policies in the `settings.validation` sections of all the real and enabled components in the stacks
+func findComponentValidationJobs(
+	stacksMap map[string]any,
+	rawStackConfigs map[string]map[string]any,
+	stacks []string,
+	components []string,
+) ([]componentValidationJob, error) {
+	var jobs []componentValidationJob
+
+	for _, stackName := range u.StringKeysFromMap(stacksMap) {
+		stackSection, ok := stacksMap[stackName].(map[string]any)
+		if !ok {
+			continue
+		}
+
+		componentsSection, ok := stackSection["components"].(map[string]any)
+		if !ok {
+			continue
+		}
+
+		for _, componentType := range []string{"terraform", "helmfile"} {
+			componentTypeSection, ok := componentsSection[componentType].(map[string]any)
+			if !ok {
+				continue
+			}
+
+			for _, componentName := range u.StringKeysFromMap(componentTypeSection) {
+				componentSection, ok := componentTypeSection[componentName].(map[string]any)
+				if !ok {
+					continue
+				}
+
+				if len(components) > 0 && !u.SliceContainsString(components, componentName) {
+					continue
+				}
+
+				stackFile, _ := componentSection["atmos_stack_file"].(string)
+
+				// The filter can contain the names of the top-level stack manifests and the logical stack names
+				if len(stacks) > 0 && !u.SliceContainsString(stacks, stackName) && !u.SliceContainsString(stacks, stackFile) {
+					continue
+				}
+
+				// Don't validate abstract and disabled components
+				componentMetadata, _, componentIsAbstract := ProcessComponentMetadata(componentName, componentSection)
+				if componentIsAbstract {
+					continue
+				}
+				varsSection, _ := componentSection["vars"].(map[any]any)
+				if !IsComponentEnabled(componentMetadata, varsSection) {
+					continue
+				}
+
+				validations, err := FindValidationSection(componentSection)
+				if err != nil {
+					return nil, errors.Wrapf(err, "invalid 'settings.validation' section of the component '%s' in the stack '%s'", componentName, stackName)
+				}
+
+				// The `sources` section is used to find the stack manifests and the lines of the policy violations
+				if _, ok := componentSection["sources"]; !ok && len(validations) > 0 {
+					sources, err := findComponentConfigSources(componentType, componentName, stackFile, componentSection, rawStackConfigs)
+					if err != nil {
+						return nil, err
+					}
+					componentSection["sources"] = sources
+				}
+
+				for policy, v := range validations {
+					if v.Disabled {
+						continue
+					}
+
+					jobs = append(jobs, componentValidationJob{
+						result: schema.ComponentValidationResult{
+							Policy:        policy,
+							Description:   v.Description,
+							SchemaType:    v.SchemaType,
+							SchemaPath:    v.SchemaPath,
+							Component:     componentName,
+							ComponentType: componentType,
+							Stack:         stackName,
+							StackFile:     stackFile,
+						},
+						componentSection: componentSection,
+						modulePaths:      v.ModulePaths,
+						timeout:          v.Timeout,
+						failOnSeverity:   v.FailOnSeverity,
+					})
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(jobs, func(i, j int) bool {
+		a := jobs[i].result
+		b := jobs[j].result
+		if a.Policy != b.Policy {
+			return a.Policy < b.Policy
+		}
+		if a.Component != b.Component {
+			return a.Component < b.Component
+		}
+		return a.Stack < b.Stack
+	})
+
+	return jobs, nil
+}
+
+// findComponentConfigSources finds the stack manifests where the sections of the component are defined
+// (the same `sources` section that `ProcessStacks` adds to the component section)
+func findComponentConfigSources(
+	componentType string,
+	componentName string,
+	stackFile string,
+	componentSection map[string]any,
+	rawStackConfigs map[string]map[string]any,
+) (schema.ConfigSources, error) {
+	configAndStacksInfo := schema.ConfigAndStacksInfo{
+		ComponentFromArg: componentName,
+		ComponentType:    componentType,
+		StackFile:        stackFile,
+	}
+
+	configAndStacksInfo.ComponentVarsSection, _ = componentSection["vars"].(map[any]any)
+	configAndStacksInfo.ComponentEnvSection, _ = componentSection["env"].(map[any]any)
+	configAndStacksInfo.ComponentSettingsSection, _ = componentSection["settings"].(map[any]any)
+	configAndStacksInfo.ComponentBackendSection, _ = componentSection["backend"].(map[any]any)
+	configAndStacksInfo.ComponentBackendType, _ = componentSection["backend_type"].(string)
+	configAndStacksInfo.ComponentMetadataSection, _ = componentSection["metadata"].(map[any]any)
+	configAndStacksInfo.ComponentInheritanceChain, _ = componentSection["inheritance"].([]string)
+
+	return ProcessConfigSources(configAndStacksInfo, rawStackConfigs)
+}
+
+// runComponentValidationJob validates the component in the stack and returns the result of the validation
+func runComponentValidationJob(cliConfig schema.CliConfiguration, job componentValidationJob) schema.ComponentValidationResult {
+	result := job.result
+
+	u.LogDebug(cliConfig, fmt.Sprintf("\nValidating the component '%s' in the stack '%s' using '%s' file '%s'",
+		result.Component, result.Stack, result.SchemaType, result.SchemaPath))
+
+	ok, policyResult, err := validateComponentWithCache(cliConfig, job.componentSection, result.SchemaPath, result.SchemaType, job.modulePaths, job.timeout, job.failOnSeverity)
+	result.Violations = policyResult.Errors
+	result.Warnings = policyResult.Warnings
+	if err != nil {
+		result.Message = err.Error()
+		return result
+	}
+
+	result.Passed = ok
+	if !ok {
+		result.Message = "validation failed"
+	}
+
+	return result
+}
@@ -0,0 +1,350 @@
+package exec
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	cfg "github.com/cloudposse/atmos/pkg/config"
+	"github.com/cloudposse/atmos/pkg/schema"
+	u "github.com/cloudposse/atmos/pkg/utils"
+)
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+)
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+type sarifReport struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationUri string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	Id               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleId    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+type sarifArtifactLocation struct {
+	Uri string `json:"uri"`
+}
+
+// WriteComponentValidationReport renders the results of the component validations in the specified format,
+// and writes the report to the file (if specified) or to the console
+func WriteComponentValidationReport(
+	cliConfig schema.CliConfiguration,
+	results []schema.ComponentValidationResult,
+	format string,
+	file string,
+) error {
+	var report string
+	var err error
+
+	switch format {
+	case "junit":
+		report, err = componentValidationReportToJUnit(results)
+	case "sarif":
+		report, err = componentValidationReportToSARIF(cliConfig, results)
+	default:
+		report = componentValidationReportToText(results)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if file == "" {
+		u.PrintMessage(report)
+		return nil
+	}
+
+	err = u.EnsureDir(file)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(file, []byte(report), 0644)
+}
+
+// componentValidationReportToText renders the results of the component validations grouped by policy, component and stack
+func componentValidationReportToText(results []schema.ComponentValidationResult) string {
+	var sb strings.Builder
+	failed := 0
+
+	for i, r := range results {
+		if i == 0 || r.Policy != results[i-1].Policy {
+			sb.WriteString(fmt.Sprintf("\npolicy: %s (%s: %s)\n", r.Policy, r.SchemaType, r.SchemaPath))
+		}
+		if i == 0 || r.Policy != results[i-1].Policy || r.Component != results[i-1].Component {
+			sb.WriteString(fmt.Sprintf("  component: %s\n", r.Component))
+		}
+
+		if r.Passed {
+			sb.WriteString(fmt.Sprintf("    PASS  %s\n", r.Stack))
+		} else {
+			failed++
+			sb.WriteString(fmt.Sprintf("    FAIL  %s\n", r.Stack))
+			for _, line := range strings.Split(strings.TrimSpace(r.Message), "\n") {
+				sb.WriteString(fmt.Sprintf("          %s\n", line))
+			}
+		}
+
+		for _, w := range r.Warnings {
+			sb.WriteString(fmt.Sprintf("          WARN %s\n", formatPolicyViolation(w)))
+		}
+	}
+
+	sb.WriteString(fmt.Sprintf("\n%d component validations: %d passed, %d failed\n", len(results), len(results)-failed, failed))
+
+	return sb.String()
+}
+
+// componentValidationReportToJUnit renders the results of the component validations in JUnit XML format.
+// Each policy is a test suite, and each component in a stack is a test case
+func componentValidationReportToJUnit(results []schema.ComponentValidationResult) (string, error) {
+	report := junitTestSuites{
+		Name: "atmos validate components",
+	}
+
+	for i, r := range results {
+		if i == 0 || r.Policy != results[i-1].Policy {
+			report.Suites = append(report.Suites, junitTestSuite{Name: r.Policy})
+		}
+		suite := &report.Suites[len(report.Suites)-1]
+
+		testCase := junitTestCase{
+			Name:      r.Stack,
+			ClassName: r.Component,
+		}
+
+		if !r.Passed {
+			testCase.Failure = &junitFailure{
+				Message: fmt.Sprintf("component '%s' in the stack '%s' failed the '%s' validation", r.Component, r.Stack, r.Policy),
+				Type:    r.SchemaType,
+				Text:    r.Message,
+			}
+			suite.Failures++
+			report.Failures++
+		}
+
+		// The warnings don't fail the test case, and are reported in the test case output
+		if len(r.Warnings) > 0 {
+			testCase.SystemOut = formatPolicyViolations(r.Warnings)
+		}
+
+		suite.TestCases = append(suite.TestCases, testCase)
+		suite.Tests++
+		report.Tests++
+	}
+
+	out, err := xml.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	return xml.Header + string(out), nil
+}
+
+// componentValidationReportToSARIF renders the failed component validations and the warnings in SARIF format.
+// Each policy is a rule, and the results point to the stack manifests
+func componentValidationReportToSARIF(cliConfig schema.CliConfiguration, results []schema.ComponentValidationResult) (string, error) {
+	run := sarifRun{
+		Tool: sarifTool{
+			Driver: sarifDriver{
+				Name:           "atmos",
+				InformationUri: "https://atmos.tools",
+				Rules:          []sarifRule{},
+			},
+		},
+		Results: []sarifResult{},
+	}
+
+	for i, r := range results {
+		if i == 0 || r.Policy != results[i-1].Policy {
+			description := r.Description
+			if description == "" {
+				description = fmt.Sprintf("%s: %s", r.SchemaType, r.SchemaPath)
+			}
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{
+				Id:               r.Policy,
+				ShortDescription: sarifMessage{Text: description},
+			})
+		}
+
+		for _, w := range r.Warnings {
+			run.Results = append(run.Results, policyViolationToSARIF(cliConfig, r, w, "warning"))
+		}
+
+		if r.Passed {
+			continue
+		}
+
+		// OPA policies report structured violations, each violation is a separate result
+		if len(r.Violations) > 0 {
+			for _, v := range r.Violations {
+				run.Results = append(run.Results, policyViolationToSARIF(cliConfig, r, v, "error"))
+			}
+			continue
+		}
+
+		result := sarifResult{
+			RuleId:    r.Policy,
+			Level:     "error",
+			Message:   sarifMessage{Text: fmt.Sprintf("component '%s' in the stack '%s': %s", r.Component, r.Stack, r.Message)},
+			Locations: stackManifestSARIFLocations(cliConfig, r.StackFile, 0),
+		}
+
+		run.Results = append(run.Results, result)
+	}
+
+	report := sarifReport{
+		Version: sarifVersion,
+		Schema:  sarifSchema,
+		Runs:    []sarifRun{run},
+	}
+
+	out, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(out), nil
+}
+
+// policyViolationToSARIF converts the OPA policy violation to a SARIF result.
+// The result points to the stack manifest where the value from the violation path is defined (if found), or to the top-level stack manifest
+func policyViolationToSARIF(
+	cliConfig schema.CliConfiguration,
+	r schema.ComponentValidationResult,
+	v schema.PolicyViolation,
+	level string,
+) sarifResult {
+	result := sarifResult{
+		RuleId:  r.Policy,
+		Level:   level,
+		Message: sarifMessage{Text: fmt.Sprintf("component '%s' in the stack '%s': %s", r.Component, r.Stack, formatPolicyViolation(v))},
+	}
+
+	if v.StackFile != "" {
+		result.Locations = stackManifestSARIFLocations(cliConfig, v.StackFile, v.Line)
+	}
+	if len(result.Locations) == 0 {
+		result.Locations = stackManifestSARIFLocations(cliConfig, r.StackFile, 0)
+	}
+
+	return result
+}
+
+// stackManifestSARIFLocations returns the SARIF location of the stack manifest, or nil if the stack manifest is not found
+func stackManifestSARIFLocations(cliConfig schema.CliConfiguration, stackFile string, line int) []sarifLocation {
+	uri := findStackManifestUri(cliConfig, stackFile)
+	if uri == "" {
+		return nil
+	}
+
+	location := sarifLocation{PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{Uri: uri}}}
+	if line > 0 {
+		location.PhysicalLocation.Region = &sarifRegion{StartLine: line}
+	}
+
+	return []sarifLocation{location}
+}
+
+// findStackManifestUri returns the path to the top-level stack manifest relative to the current directory
+func findStackManifestUri(cliConfig schema.CliConfiguration, stackFile string) string {
+	if stackFile == "" {
+		return ""
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return ""
+	}
+
+	for _, ext := range cfg.StackConfigFileExtensions {
+		filePath := path.Join(cliConfig.StacksBaseAbsolutePath, stackFile+ext)
+		if !u.FileExists(filePath) {
+			continue
+		}
+
+		relativePath, err := filepath.Rel(cwd, filePath)
+		if err != nil {
+			return filepath.ToSlash(filePath)
+		}
+		return filepath.ToSlash(relativePath)
+	}
+
+	return ""
+}
@@ -0,0 +1,54 @@
+package exec
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	cfg "github.com/cloudposse/atmos/pkg/config"
+	"github.com/cloudposse/atmos/pkg/schema"
+	u "github.com/cloudposse/atmos/pkg/utils"
+)
+
+// ExecuteValidateConfigCmd executes `validate config` command
+func ExecuteValidateConfigCmd(cmd *cobra.Command, args []string) error {
+	info, err := processCommandLineArgs("", cmd, args, nil)
+	if err != nil {
+		return err
+	}
+
+	// The CLI config files are validated without decoding the merged config, since decoding fails on the invalid values
+	configFiles, err := cfg.FindCliConfigFiles(info)
+	if err != nil {
+		return err
+	}
+
+	if len(configFiles) == 0 {
+		return cfg.NotFound
+	}
+
+	return ExecuteValidateConfig(configFiles)
+}
+
+// ExecuteValidateConfig validates the CLI config files (system dir, home dir, current dir, `ATMOS_CLI_CONFIG_PATH`,
+// the `--config` flag) using the bundled JSON Schema
+func ExecuteValidateConfig(configFiles []string) error {
+	u.LogDebug(schema.CliConfiguration{}, fmt.Sprintf("Validating the CLI config files:\n%s", strings.Join(configFiles, "\n")))
+
+	errs, err := cfg.ValidateCliConfigFiles(configFiles)
+	if err != nil {
+		return err
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	messages := make([]string, 0, len(errs))
+	for _, e := range errs {
+		messages = append(messages, e.Error())
+	}
+
+	return fmt.Errorf("%d error(s) found in the CLI config files:\n%s", len(errs), strings.Join(messages, "\n"))
+}
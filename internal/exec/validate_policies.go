@@ -0,0 +1,133 @@
+package exec
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	cfg "github.com/cloudposse/atmos/pkg/config"
+	"github.com/cloudposse/atmos/pkg/schema"
+	u "github.com/cloudposse/atmos/pkg/utils"
+)
+
+// ExecuteValidatePoliciesCmd executes `validate policies` command
+func ExecuteValidatePoliciesCmd(cmd *cobra.Command, args []string) error {
+	info, err := processCommandLineArgs("", cmd, args, nil)
+	if err != nil {
+		return err
+	}
+
+	cliConfig, err := cfg.InitCliConfig(info, true)
+	if err != nil {
+		return err
+	}
+
+	flags := cmd.Flags()
+
+	policiesCsv, err := flags.GetString("policies")
+	if err != nil {
+		return err
+	}
+	var policies []string
+	if policiesCsv != "" {
+		policies = strings.Split(policiesCsv, ",")
+	}
+
+	return ExecuteValidatePolicies(cliConfig, policies)
+}
+
+// ExecuteValidatePolicies validates the stacks using the policies from the `validate.policies` section in `atmos.yaml`.
+// The input of the policies with the `repo` scope is the `describe stacks` output (all stacks),
+// and the policies with the `stack` scope are evaluated for each stack separately
+func ExecuteValidatePolicies(cliConfig schema.CliConfiguration, policies []string) error {
+	for _, policy := range policies {
+		if _, ok := cliConfig.Validate.Policies[policy]; !ok {
+			return fmt.Errorf("the policy '%s' is not defined in the 'validate.policies' section in 'atmos.yaml'", policy)
+		}
+	}
+
+	var policyNames []string
+	for name, p := range cliConfig.Validate.Policies {
+		if p.Disabled || (len(policies) > 0 && !u.SliceContainsString(policies, name)) {
+			continue
+		}
+		if p.Scope != "" && p.Scope != "repo" && p.Scope != "stack" {
+			return fmt.Errorf("invalid 'scope' '%s' of the policy '%s' in the 'validate.policies' section in 'atmos.yaml'. "+
+				"Supported scopes: repo, stack", p.Scope, name)
+		}
+		policyNames = append(policyNames, name)
+	}
+
+	if len(policyNames) == 0 {
+		return nil
+	}
+
+	stacksMap, err := ExecuteDescribeStacks(cliConfig, "", nil, nil, nil, false)
+	if err != nil {
+		return err
+	}
+
+	var errorMessages []string
+
+	sort.Strings(policyNames)
+
+	for _, name := range policyNames {
+		p := cliConfig.Validate.Policies[name]
+
+		u.LogDebug(cliConfig, fmt.Sprintf("\nValidating the stacks using the policy '%s' ('%s' file '%s')", name, p.SchemaType, p.SchemaPath))
+
+		if p.Scope != "stack" {
+			err = validateStacksWithPolicy(cliConfig, name, p, stacksMap)
+			if err != nil {
+				errorMessages = append(errorMessages, fmt.Sprintf("policy '%s' failed:\n%v", name, err))
+			}
+			continue
+		}
+
+		for _, stackName := range u.StringKeysFromMap(stacksMap) {
+			stackSection, ok := stacksMap[stackName].(map[string]any)
+			if !ok {
+				continue
+			}
+
+			// The stack policies get the stack name in the `atmos_stack` attribute (similar to the component config)
+			input := map[string]any{"atmos_stack": stackName}
+			for k, v := range stackSection {
+				input[k] = v
+			}
+
+			err = validateStacksWithPolicy(cliConfig, name, p, input)
+			if err != nil {
+				errorMessages = append(errorMessages, fmt.Sprintf("policy '%s' failed in the stack '%s':\n%v", name, stackName, err))
+			}
+		}
+	}
+
+	if len(errorMessages) > 0 {
+		return errors.New(strings.Join(errorMessages, "\n\n"))
+	}
+
+	return nil
+}
+
+// validateStacksWithPolicy validates the stacks config using the policy and logs the warnings reported by the policy
+func validateStacksWithPolicy(cliConfig schema.CliConfiguration, name string, p schema.StackPolicy, input map[string]any) error {
+	ok, result, err := validateComponentInternal(cliConfig, input, p.SchemaPath, p.SchemaType, p.ModulePaths, p.Timeout, p.FailOnSeverity)
+
+	for _, w := range result.Warnings {
+		u.LogWarning(cliConfig, fmt.Sprintf("policy '%s': %s", name, formatPolicyViolation(w)))
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return errors.New("validation failed")
+	}
+
+	return nil
+}
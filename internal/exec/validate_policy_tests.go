@@ -0,0 +1,406 @@
+package exec
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/open-policy-agent/opa/tester"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+
+	cfg "github.com/cloudposse/atmos/pkg/config"
+	"github.com/cloudposse/atmos/pkg/schema"
+	u "github.com/cloudposse/atmos/pkg/utils"
+)
+
+// ExecuteValidateTestCmd executes `validate test` command
+func ExecuteValidateTestCmd(cmd *cobra.Command, args []string) error {
+	info, err := processCommandLineArgs("", cmd, args, nil)
+	if err != nil {
+		return err
+	}
+
+	cliConfig, err := cfg.InitCliConfig(info, true)
+	if err != nil {
+		return err
+	}
+
+	flags := cmd.Flags()
+
+	run, err := flags.GetString("run")
+	if err != nil {
+		return err
+	}
+
+	modulePaths, err := flags.GetStringSlice("module-paths")
+	if err != nil {
+		return err
+	}
+
+	results, err := ExecuteValidateTest(cliConfig, run, modulePaths)
+	if err != nil {
+		return err
+	}
+
+	u.PrintMessage(validationTestResultsToText(results))
+
+	failed := 0
+	for _, r := range results {
+		if !r.Passed && !r.Skipped {
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d tests failed", failed, len(results))
+	}
+
+	return nil
+}
+
+// ExecuteValidateTest runs the OPA tests (`test_` rules in the `*_test.rego` files) in the OPA schemas base path,
+// and the fixture-based tests (`*_test.yaml` files) in the JSON Schema, OPA and CUE schemas base paths.
+// If `run` is specified, only the tests with the names matching the regular expression are executed
+func ExecuteValidateTest(
+	cliConfig schema.CliConfiguration,
+	run string,
+	modulePaths []string,
+) ([]schema.ValidationTestResult, error) {
+	var runRegexp *regexp.Regexp
+	if run != "" {
+		var err error
+		if runRegexp, err = regexp.Compile(run); err != nil {
+			return nil, errors.Wrapf(err, "invalid '--run' flag '%s'", run)
+		}
+	}
+
+	var results []schema.ValidationTestResult
+
+	opaBasePath := path.Join(cliConfig.BasePath, cliConfig.Schemas.Opa.BasePath)
+
+	if cliConfig.Schemas.Opa.BasePath != "" && u.FileOrDirExists(opaBasePath) {
+		regoTestFiles, err := findValidationTestFiles(opaBasePath, []string{"_test.rego"})
+		if err != nil {
+			return nil, err
+		}
+
+		modulePathsAbsolute, err := u.JoinAbsolutePathWithPaths(opaBasePath, modulePaths)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, f := range regoTestFiles {
+			r, err := runOpaTests(cliConfig, f, modulePathsAbsolute, run)
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, r...)
+		}
+	}
+
+	// The fixture-based tests can be placed in any of the schemas base paths
+	var fixtureFiles []string
+	for _, basePath := range []string{
+		cliConfig.Schemas.JsonSchema.BasePath,
+		cliConfig.Schemas.Opa.BasePath,
+		cliConfig.Schemas.Cue.BasePath,
+	} {
+		if basePath == "" || !u.FileOrDirExists(path.Join(cliConfig.BasePath, basePath)) {
+			continue
+		}
+
+		files, err := findValidationTestFiles(path.Join(cliConfig.BasePath, basePath), []string{"_test.yaml", "_test.yml"})
+		if err != nil {
+			return nil, err
+		}
+		fixtureFiles = append(fixtureFiles, files...)
+	}
+
+	for _, f := range u.UniqueStrings(fixtureFiles) {
+		r, err := runValidationFixtureTests(cliConfig, f, runRegexp)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, r...)
+	}
+
+	return results, nil
+}
+
+// findValidationTestFiles finds the files with the specified suffixes in the folder and all subfolders
+func findValidationTestFiles(dir string, suffixes []string) ([]string, error) {
+	var files []string
+
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		for _, suffix := range suffixes {
+			if strings.HasSuffix(d.Name(), suffix) {
+				files = append(files, p)
+				break
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(files)
+	return files, nil
+}
+
+// runOpaTests runs the OPA tests in the `<policy>_test.rego` file.
+// The tests are evaluated together with the policy in the `<policy>.rego` file in the same folder and the additional modules
+// (the same way as the policy is evaluated in the `settings.validation` section)
+func runOpaTests(cliConfig schema.CliConfiguration, testFile string, modulePaths []string, run string) ([]schema.ValidationTestResult, error) {
+	paths := []string{testFile}
+
+	policyFile := strings.TrimSuffix(testFile, "_test.rego") + ".rego"
+	if u.FileExists(policyFile) {
+		paths = append(paths, policyFile)
+	}
+	paths = append(paths, modulePaths...)
+
+	// Load only the Rego files, and don't load the other tests from the module paths
+	filter := func(abspath string, info fs.FileInfo, depth int) bool {
+		if info.IsDir() {
+			return false
+		}
+		if filepath.Ext(abspath) != ".rego" {
+			return true
+		}
+		return strings.HasSuffix(abspath, "_test.rego") && filepath.Clean(abspath) != filepath.Clean(testFile)
+	}
+
+	relativeTestFile := u.TrimBasePathFromPath(cliConfig.BasePath+"/", testFile)
+
+	modules, store, err := tester.Load(paths, filter)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to load the OPA tests from the file '%s'", relativeTestFile)
+	}
+
+	runner := tester.NewRunner().SetStore(store).SetTimeout(20 * time.Second)
+	if run != "" {
+		runner = runner.Filter(run)
+	}
+
+	ch, err := runner.Run(context.Background(), modules)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to run the OPA tests from the file '%s'", relativeTestFile)
+	}
+
+	var results []schema.ValidationTestResult
+
+	for r := range ch {
+		result := schema.ValidationTestResult{
+			Type:    "opa",
+			File:    relativeTestFile,
+			Name:    fmt.Sprintf("%s.%s", r.Package, r.Name),
+			Passed:  r.Pass(),
+			Skipped: r.Skip,
+		}
+
+		if r.Error != nil {
+			result.Messages = append(result.Messages, r.Error.Error())
+		} else if r.Fail && r.FailedAt != nil {
+			result.Messages = append(result.Messages, fmt.Sprintf("failed at %s:%d: %s", relativeTestFile, r.FailedAt.Location.Row, r.FailedAt.String()))
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// runValidationFixtureTests runs the fixture-based tests from the `*_test.yaml` file
+func runValidationFixtureTests(cliConfig schema.CliConfiguration, file string, runRegexp *regexp.Regexp) ([]schema.ValidationTestResult, error) {
+	relativeFile := u.TrimBasePathFromPath(cliConfig.BasePath+"/", file)
+
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var testFile schema.ValidationTestFile
+	if err = yaml.Unmarshal(content, &testFile); err != nil {
+		return nil, errors.Wrapf(err, "invalid validation test file '%s'", relativeFile)
+	}
+
+	var results []schema.ValidationTestResult
+
+	for i, test := range testFile.Tests {
+		if test.Name == "" {
+			test.Name = fmt.Sprintf("test-%d", i+1)
+		}
+
+		if runRegexp != nil && !runRegexp.MatchString(test.Name) {
+			continue
+		}
+
+		result := schema.ValidationTestResult{
+			Type: "fixture",
+			File: relativeFile,
+			Name: test.Name,
+		}
+
+		result.Messages = runValidationFixtureTest(cliConfig, test)
+		result.Passed = len(result.Messages) == 0
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// runValidationFixtureTest validates the component config from the test, and returns the differences between the actual and the expected
+// errors and warnings. Each expected error must be a substring of an actual error, and each actual error must match an expected error
+func runValidationFixtureTest(cliConfig schema.CliConfiguration, test schema.ValidationTest) []string {
+	var componentSection map[string]any
+
+	if test.Component != "" {
+		if test.Stack == "" {
+			return []string{"'stack' is required when 'component' is specified"}
+		}
+
+		var err error
+		componentSection, err = findComponentSectionInStack(cliConfig, schema.ConfigAndStacksInfo{}, test.Component, test.Stack)
+		if err != nil {
+			return []string{err.Error()}
+		}
+	} else if test.Config != nil {
+		componentSection = test.Config
+	} else {
+		return []string{"either 'component' and 'stack', or 'config' must be specified"}
+	}
+
+	// If the schema is not specified, the test uses the policies from the `settings.validation` section of the component
+	var validations schema.Validation
+	if test.SchemaPath != "" {
+		validations = schema.Validation{
+			test.SchemaPath: schema.ValidationItem{
+				SchemaType:     test.SchemaType,
+				SchemaPath:     test.SchemaPath,
+				ModulePaths:    test.ModulePaths,
+				Timeout:        test.Timeout,
+				FailOnSeverity: test.FailOnSeverity,
+			},
+		}
+	} else {
+		var err error
+		if validations, err = FindValidationSection(componentSection); err != nil {
+			return []string{err.Error()}
+		}
+		if len(validations) == 0 {
+			return []string{"the component does not have the 'settings.validation' section, and 'schema_path' is not specified"}
+		}
+	}
+
+	var actualErrors []string
+	var actualWarnings []string
+
+	for _, v := range validations {
+		if v.Disabled {
+			continue
+		}
+
+		_, result, err := validateComponentInternal(cliConfig, componentSection, v.SchemaPath, v.SchemaType, v.ModulePaths, v.Timeout, v.FailOnSeverity)
+
+		for _, w := range result.Warnings {
+			actualWarnings = append(actualWarnings, w.Message)
+		}
+
+		// The OPA policies return structured errors, the other validation errors are compared as a whole
+		if len(result.Errors) > 0 {
+			for _, e := range result.Errors {
+				actualErrors = append(actualErrors, e.Message)
+			}
+		} else if err != nil {
+			actualErrors = append(actualErrors, err.Error())
+		}
+	}
+
+	var messages []string
+	messages = append(messages, compareValidationTestMessages("error", test.ExpectedErrors, actualErrors)...)
+	messages = append(messages, compareValidationTestMessages("warning", test.ExpectedWarnings, actualWarnings)...)
+	return messages
+}
+
+// compareValidationTestMessages returns the expected messages that were not reported, and the reported messages that were not expected
+func compareValidationTestMessages(kind string, expected []string, actual []string) []string {
+	var messages []string
+
+	for _, e := range expected {
+		found := false
+		for _, a := range actual {
+			if strings.Contains(a, e) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			messages = append(messages, fmt.Sprintf("expected %s was not reported: %s", kind, e))
+		}
+	}
+
+	for _, a := range actual {
+		found := false
+		for _, e := range expected {
+			if strings.Contains(a, e) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			messages = append(messages, fmt.Sprintf("unexpected %s: %s", kind, a))
+		}
+	}
+
+	return messages
+}
+
+// validationTestResultsToText renders the test results, followed by a summary
+func validationTestResultsToText(results []schema.ValidationTestResult) string {
+	var sb strings.Builder
+	passed := 0
+	failed := 0
+	skipped := 0
+
+	for _, r := range results {
+		status := "PASS"
+		switch {
+		case r.Skipped:
+			status = "SKIP"
+			skipped++
+		case r.Passed:
+			passed++
+		default:
+			status = "FAIL"
+			failed++
+		}
+
+		sb.WriteString(fmt.Sprintf("%s  %s: %s\n", status, r.File, r.Name))
+		for _, m := range r.Messages {
+			for _, line := range strings.Split(strings.TrimSpace(m), "\n") {
+				sb.WriteString(fmt.Sprintf("      %s\n", line))
+			}
+		}
+	}
+
+	sb.WriteString(fmt.Sprintf("\n%d tests: %d passed, %d failed, %d skipped\n", len(results), passed, failed, skipped))
+
+	return sb.String()
+}
@@ -0,0 +1,59 @@
+package exec
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	cfg "github.com/cloudposse/atmos/pkg/config"
+	"github.com/cloudposse/atmos/pkg/schema"
+	u "github.com/cloudposse/atmos/pkg/utils"
+)
+
+// ExecuteValidateSchemaExportCmd executes `validate schema export` command
+func ExecuteValidateSchemaExportCmd(cmd *cobra.Command, args []string) error {
+	flags := cmd.Flags()
+
+	schemaType, err := flags.GetString("type")
+	if err != nil {
+		return err
+	}
+
+	file, err := flags.GetString("file")
+	if err != nil {
+		return err
+	}
+
+	jsonSchema, err := ExecuteValidateSchemaExport(schemaType)
+	if err != nil {
+		return err
+	}
+
+	if file == "" {
+		u.PrintMessage(jsonSchema)
+		return nil
+	}
+
+	u.LogDebug(schema.CliConfiguration{}, fmt.Sprintf("Writing the '%s' JSON Schema to the file '%s'", schemaType, file))
+
+	err = u.EnsureDir(file)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(file, []byte(jsonSchema), 0644)
+}
+
+// ExecuteValidateSchemaExport returns the JSON Schema bundled with Atmos.
+// `schemaType` can be `manifest` (Atmos stack manifests) or `config` (Atmos CLI config files)
+func ExecuteValidateSchemaExport(schemaType string) (string, error) {
+	switch schemaType {
+	case "", "manifest":
+		return cfg.AtmosManifestJsonSchema, nil
+	case "config":
+		return cfg.CliConfigJsonSchema, nil
+	default:
+		return "", fmt.Errorf("invalid '--type' flag '%s'. Valid values are 'manifest' and 'config'", schemaType)
+	}
+}
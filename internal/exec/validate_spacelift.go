@@ -0,0 +1,159 @@
+package exec
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	cfg "github.com/cloudposse/atmos/pkg/config"
+	"github.com/cloudposse/atmos/pkg/schema"
+	s "github.com/cloudposse/atmos/pkg/stack"
+	u "github.com/cloudposse/atmos/pkg/utils"
+)
+
+// invalidSpaceliftStackNameChars matches the characters that Spacelift replaces when it builds the stack ID (slug) from the stack name.
+// The stack names with these characters can't be referenced by the `depends-on:<stack>` labels
+var invalidSpaceliftStackNameChars = regexp.MustCompile(`[^a-zA-Z0-9_-]`)
+
+// spaceliftStackSlugChars matches the characters that are replaced with `-` in the Spacelift stack ID (slug)
+var spaceliftStackSlugChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// ExecuteValidateSpaceliftCmd executes `validate spacelift` command
+func ExecuteValidateSpaceliftCmd(cmd *cobra.Command, args []string) error {
+	info, err := processCommandLineArgs("", cmd, args, nil)
+	if err != nil {
+		return err
+	}
+
+	cliConfig, err := cfg.InitCliConfig(info, true)
+	if err != nil {
+		return err
+	}
+
+	flags := cmd.Flags()
+
+	stackConfigPathTemplate, err := flags.GetString("stack-config-path-template")
+	if err != nil {
+		return err
+	}
+
+	maxLabelLength, err := flags.GetInt("max-label-length")
+	if err != nil {
+		return err
+	}
+
+	errorMessages, err := ExecuteValidateSpacelift(cliConfig, stackConfigPathTemplate, maxLabelLength)
+	if err != nil {
+		return err
+	}
+
+	if len(errorMessages) > 0 {
+		return fmt.Errorf("%d error(s) found in the Spacelift stacks:\n\n%s", len(errorMessages), strings.Join(errorMessages, "\n\n"))
+	}
+
+	return nil
+}
+
+// ExecuteValidateSpacelift computes the names and labels of all Spacelift stacks using `TransformStackConfigToSpaceliftStacks`,
+// and returns the error messages for the duplicate stack names (including the names that produce the same Spacelift stack ID),
+// the names with invalid characters, the `depends_on` dependencies on the components that are not defined in the stacks,
+// and the labels that are empty or longer than `maxLabelLength`.
+// The dependencies on the components that don't have Spacelift stacks are skipped with a warning (the same as in `atmos spacelift generate stacks`)
+func ExecuteValidateSpacelift(cliConfig schema.CliConfiguration, stackConfigPathTemplate string, maxLabelLength int) ([]string, error) {
+	_, stacks, rawStackConfigs, err := s.ProcessYAMLConfigFiles(
+		cliConfig.StacksBaseAbsolutePath,
+		cliConfig.TerraformDirAbsolutePath,
+		cliConfig.HelmfileDirAbsolutePath,
+		cliConfig.StackConfigFilesAbsolutePaths,
+		true,
+		true,
+		false,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// The dependencies on the components that are not defined in the stacks, and the duplicate Spacelift stack names
+	spaceliftStacks, stackErrors, err := transformStackConfigToSpaceliftStacks(
+		stacks,
+		stackConfigPathTemplate,
+		cliConfig.Stacks.NamePattern,
+		true,
+		rawStackConfigs,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var errorMessages []string
+	for _, stackError := range stackErrors {
+		errorMessages = append(errorMessages, strings.TrimSpace(stackError.Error()))
+	}
+
+	// Spacelift builds the stack ID (slug) from the name, so the names that differ only in case or in the special characters are also duplicates
+	stacksBySlug := map[string][]string{}
+
+	for _, spaceliftStackName := range u.StringKeysFromMap(spaceliftStacks) {
+		spaceliftConfig, ok := spaceliftStacks[spaceliftStackName].(map[string]any)
+		if !ok {
+			continue
+		}
+
+		component, _ := spaceliftConfig["component"].(string)
+		stack, _ := spaceliftConfig["stack"].(string)
+
+		if invalidSpaceliftStackNameChars.MatchString(spaceliftStackName) {
+			errorMessages = append(errorMessages, fmt.Sprintf(
+				"the Spacelift stack name '%s' for the component '%s' in the stack '%s' contains invalid characters. "+
+					"Only letters, digits, '-' and '_' are allowed. Check the Spacelift stack name pattern 'settings.spacelift.stack_name_pattern'",
+				spaceliftStackName, component, stack))
+		}
+
+		slug := spaceliftStackSlug(spaceliftStackName)
+		stacksBySlug[slug] = append(stacksBySlug[slug],
+			fmt.Sprintf("'%s' for the component '%s' in the stack '%s'", spaceliftStackName, component, stack))
+
+		// Log the warnings for the dependencies on the components that don't have Spacelift stacks
+		findSpaceliftStackDependsOn(cliConfig, spaceliftStacks, spaceliftStackName)
+
+		labels, _ := spaceliftConfig["labels"].([]string)
+
+		for _, label := range labels {
+			if strings.TrimSpace(label) == "" {
+				errorMessages = append(errorMessages, fmt.Sprintf("the Spacelift stack '%s' has an empty label", spaceliftStackName))
+			} else if maxLabelLength > 0 && len(label) > maxLabelLength {
+				errorMessages = append(errorMessages, fmt.Sprintf("the label '%s' of the Spacelift stack '%s' is %d characters long, "+
+					"which exceeds the maximum label length of %d characters",
+					label, spaceliftStackName, len(label), maxLabelLength))
+			}
+		}
+	}
+
+	var slugs []string
+	for slug := range stacksBySlug {
+		slugs = append(slugs, slug)
+	}
+	sort.Strings(slugs)
+
+	for _, slug := range slugs {
+		duplicates := stacksBySlug[slug]
+		if len(duplicates) < 2 {
+			continue
+		}
+
+		errorMessages = append(errorMessages, fmt.Sprintf(
+			"duplicate Spacelift stack ID '%s' for the Spacelift stacks:\n%s\n"+
+				"Check if the component names are correct and the Spacelift stack name patterns are specific enough",
+			slug, strings.Join(duplicates, "\n")))
+	}
+
+	return errorMessages, nil
+}
+
+// spaceliftStackSlug returns the Spacelift stack ID (slug) built from the stack name
+func spaceliftStackSlug(name string) string {
+	return strings.Trim(spaceliftStackSlugChars.ReplaceAllString(strings.ToLower(name), "-"), "-")
+}
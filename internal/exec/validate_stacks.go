@@ -3,12 +3,14 @@ package exec
 import (
 	"fmt"
 	"path"
+	"path/filepath"
 	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
 	cfg "github.com/cloudposse/atmos/pkg/config"
+	"github.com/cloudposse/atmos/pkg/schema"
 	s "github.com/cloudposse/atmos/pkg/stack"
 	u "github.com/cloudposse/atmos/pkg/utils"
 )
@@ -36,9 +38,28 @@ func ExecuteValidateStacksCmd(cmd *cobra.Command, args []string) error {
 		cliConfig.Schemas.Atmos.Manifest = schemasAtmosManifestFlag
 	}
 
+	if flags.Changed("strict") {
+		strictFlag, err := flags.GetBool("strict")
+		if err != nil {
+			return err
+		}
+		cliConfig.Validate.Stacks.Strict = strictFlag
+	}
+
+	validateVarsFlag, err := flags.GetBool("validate")
+	if err != nil {
+		return err
+	}
+
+	checkVarsFlag, err := flags.GetBool("check-vars")
+	if err != nil {
+		return err
+	}
+
 	// Check if the Atmos manifest JSON Schema is configured and the file exists
-	// The path to the Atmos manifest JSON Schema can be absolute path or a path relative to the `base_path` setting in `atmos.yaml`
-	var atmosManifestJsonSchemaFilePath string
+	// The path to the Atmos manifest JSON Schema can be absolute path or a path relative to the `base_path` setting in `atmos.yaml`.
+	// If the schema is not configured, the Atmos manifest JSON Schema bundled with Atmos is used
+	atmosManifestJsonSchemaFilePath := cfg.EmbeddedAtmosManifestJsonSchemaPath
 
 	if cliConfig.Schemas.Atmos.Manifest != "" {
 		atmosManifestJsonSchemaFileAbsPath := path.Join(cliConfig.BasePath, cliConfig.Schemas.Atmos.Manifest)
@@ -58,8 +79,30 @@ func ExecuteValidateStacksCmd(cmd *cobra.Command, args []string) error {
 
 	// Include (process and validate) all YAML files in the `stacks` folder in all subfolders
 	includedPaths := []string{"**/*"}
-	// Don't exclude any YAML files for validation
-	excludedPaths := []string{}
+	// Don't exclude any stack manifests for validation, but exclude the validation schemas and policies
+	// (e.g. JSON Schemas in JSON format) if they are placed in the `stacks` folder
+	var excludedPaths []string
+	for _, schemasBasePath := range []string{
+		cliConfig.Schemas.JsonSchema.BasePath,
+		cliConfig.Schemas.Opa.BasePath,
+		cliConfig.Schemas.Cue.BasePath,
+	} {
+		if schemasBasePath == "" {
+			continue
+		}
+		schemasBaseAbsPath, err := filepath.Abs(path.Join(cliConfig.BasePath, schemasBasePath))
+		if err != nil {
+			return err
+		}
+		excludedPaths = append(excludedPaths, path.Join(schemasBaseAbsPath, "**", "*"))
+	}
+	if atmosManifestJsonSchemaFilePath != cfg.EmbeddedAtmosManifestJsonSchemaPath {
+		atmosManifestJsonSchemaFileAbsPath, err := filepath.Abs(atmosManifestJsonSchemaFilePath)
+		if err != nil {
+			return err
+		}
+		excludedPaths = append(excludedPaths, atmosManifestJsonSchemaFileAbsPath)
+	}
 	includeStackAbsPaths, err := u.JoinAbsolutePathWithPaths(cliConfig.StacksBaseAbsolutePath, includedPaths)
 	if err != nil {
 		return err
@@ -73,10 +116,66 @@ func ExecuteValidateStacksCmd(cmd *cobra.Command, args []string) error {
 	u.LogDebug(cliConfig, fmt.Sprintf("Validating all YAML files in the '%s' folder and all subfolders\n",
 		path.Join(cliConfig.BasePath, cliConfig.Stacks.BasePath)))
 
+	errorMessages, err := ValidateStackManifests(cliConfig, stackConfigFilesAbsolutePaths, atmosManifestJsonSchemaFilePath)
+	if err != nil {
+		return err
+	}
+
+	// Validate the stacks using the stack policies from the `validate.policies` section in `atmos.yaml`
+	err = ExecuteValidatePolicies(cliConfig, nil)
+	if err != nil {
+		errorMessages = append(errorMessages, err.Error())
+	}
+
+	// Validate the `vars` sections of the Terraform components using the JSON Schemas generated from the components' variables
+	if validateVarsFlag {
+		varsErrorMessages, err := ValidateComponentVarsWithGeneratedSchemas(cliConfig)
+		if err != nil {
+			errorMessages = append(errorMessages, err.Error())
+		}
+		errorMessages = append(errorMessages, varsErrorMessages...)
+	}
+
+	// Check the `vars` sections of the Terraform components for undeclared variables and required variables without values
+	if checkVarsFlag {
+		varsErrorMessages, err := CheckComponentVars(cliConfig)
+		if err != nil {
+			errorMessages = append(errorMessages, err.Error())
+		}
+		errorMessages = append(errorMessages, varsErrorMessages...)
+	}
+
+	if len(errorMessages) > 0 {
+		return errors.New(strings.Join(errorMessages, "\n\n"))
+	}
+
+	return nil
+}
+
+// ValidateStackManifests processes and validates the stack manifests, and returns the error messages.
+// In strict mode (`validate.stacks.strict` in `atmos.yaml`), the stack manifests are also checked for unknown sections
+func ValidateStackManifests(
+	cliConfig schema.CliConfiguration,
+	stackConfigFilesAbsolutePaths []string,
+	atmosManifestJsonSchemaFilePath string,
+) ([]string, error) {
+	// Workflow manifests can be placed in the `stacks` folder, they are not checked for unknown sections in strict mode.
+	// If `workflows.base_path` is not set, no folder is excluded (otherwise the whole base path would be excluded)
+	var workflowsDirAbsPath string
+	if cliConfig.Workflows.BasePath != "" {
+		var err error
+		workflowsDirAbsPath, err = filepath.Abs(path.Join(cliConfig.BasePath, cliConfig.Workflows.BasePath))
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	var errorMessages []string
 
+	s.ResetStackManifestImports()
+
 	for _, filePath := range stackConfigFilesAbsolutePaths {
-		stackConfig, importsConfig, _, err := s.ProcessYAMLConfigFile(
+		stackConfig, importsConfig, rawStackConfig, err := s.ProcessYAMLConfigFile(
 			cliConfig.StacksBaseAbsolutePath,
 			filePath,
 			map[string]map[any]any{},
@@ -92,6 +191,13 @@ func ExecuteValidateStacksCmd(cmd *cobra.Command, args []string) error {
 			errorMessages = append(errorMessages, err.Error())
 		}
 
+		// In strict mode, check the stack manifest for unknown (e.g. misspelled) sections
+		if cliConfig.Validate.Stacks.Strict && (workflowsDirAbsPath == "" || !strings.HasPrefix(filePath, workflowsDirAbsPath+"/")) {
+			for _, e := range s.FindUnknownStackManifestSections(cliConfig.StacksBaseAbsolutePath, filePath, rawStackConfig) {
+				errorMessages = append(errorMessages, e.Error())
+			}
+		}
+
 		// Process and validate the stack manifest
 		componentStackMap := map[string]map[string][]string{}
 		_, err = s.ProcessStackConfig(
@@ -111,9 +217,5 @@ func ExecuteValidateStacksCmd(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	if len(errorMessages) > 0 {
-		return errors.New(strings.Join(errorMessages, "\n\n"))
-	}
-
-	return nil
+	return errorMessages, nil
 }
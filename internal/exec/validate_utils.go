@@ -5,16 +5,24 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"path"
+	"path/filepath"
 	"strings"
 	"time"
 
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/cuecontext"
+	cueErrors "cuelang.org/go/cue/errors"
+	"cuelang.org/go/cue/load"
 	"github.com/open-policy-agent/opa/loader"
 	"github.com/open-policy-agent/opa/rego"
 	"github.com/open-policy-agent/opa/sdk"
 	opaTestServer "github.com/open-policy-agent/opa/sdk/test"
 	"github.com/pkg/errors"
 	"github.com/santhosh-tekuri/jsonschema/v5"
+	yamlv3 "gopkg.in/yaml.v3"
 
+	"github.com/cloudposse/atmos/pkg/schema"
 	u "github.com/cloudposse/atmos/pkg/utils"
 )
 
@@ -62,16 +70,39 @@ func ValidateWithJsonSchema(data any, schemaName string, schemaText string) (boo
 	return true, nil
 }
 
-// ValidateWithOpa validates the data structure using the provided OPA document
+// ValidateWithOpa validates the data structure using the provided OPA document.
+// The validation fails if the policy returns any errors in the `data.atmos.errors` output
+func ValidateWithOpa(
+	data any,
+	schemaPath string,
+	modulePaths []string,
+	timeoutSeconds int,
+) (bool, error) {
+	result, err := EvaluateOpaPolicy(data, schemaPath, modulePaths, timeoutSeconds)
+	if err != nil {
+		return false, err
+	}
+
+	if len(result.Errors) > 0 {
+		return false, errors.New(formatPolicyViolations(result.Errors))
+	}
+
+	return true, nil
+}
+
+// EvaluateOpaPolicy evaluates the provided OPA document and returns the errors (`data.atmos.errors`),
+// the warnings (`data.atmos.warnings`) and the minimum severity of the errors that fail the validation (`data.atmos.fail_on_severity`).
+// The errors and warnings can be strings or objects with the `msg`, `path`, `severity` and `rule_id` attributes
 // https://github.com/open-policy-agent/opa/blob/main/rego/example_test.go
 // https://github.com/open-policy-agent/opa/blob/main/rego/rego_test.go
 // https://www.openpolicyagent.org/docs/latest/integration/#sdk
-func ValidateWithOpa(
+func EvaluateOpaPolicy(
 	data any,
 	schemaPath string,
 	modulePaths []string,
 	timeoutSeconds int,
-) (bool, error) {
+) (schema.PolicyResult, error) {
+	result := schema.PolicyResult{}
 
 	// Set timeout for schema validation
 	if timeoutSeconds == 0 {
@@ -91,19 +122,22 @@ func ValidateWithOpa(
 	// Load the input document
 	j, err := u.ConvertToJSON(data)
 	if err != nil {
-		return false, err
+		return result, err
 	}
 
 	var input any
 	dec := json.NewDecoder(bytes.NewBufferString(j))
 	dec.UseNumber()
 	if err = dec.Decode(&input); err != nil {
-		return false, err
+		return result, err
 	}
 
 	// Construct a Rego object that can be prepared or evaluated.
+	// `errors` is required, `warnings` and `fail_on_severity` are optional (the comprehensions return empty arrays if they are not defined)
 	r := rego.New(
-		rego.Query("data.atmos.errors"),
+		rego.Query("errors := data.atmos.errors; "+
+			"warnings := [w | w := data.atmos.warnings[_]]; "+
+			"fail_on_severity := [s | s := data.atmos.fail_on_severity]"),
 		rego.Load(append([]string{schemaPath}, modulePaths...),
 			loader.GlobExcludeName("*_test.rego", 0),
 		),
@@ -112,7 +146,7 @@ func ValidateWithOpa(
 	// Create a prepared query that can be evaluated
 	query, err := r.PrepareForEval(ctx)
 	if err != nil {
-		return false, err
+		return result, err
 	}
 
 	// Execute the prepared query
@@ -121,27 +155,34 @@ func ValidateWithOpa(
 		if err.Error() == "context deadline exceeded" {
 			err = errors.New(timeoutErrorMessage)
 		}
-		return false, err
+		return result, err
 	}
 
 	if len(rs) < 1 {
-		return false, errors.New(invalidRegoPolicyErrorMessage)
-	}
-
-	if len(rs[0].Expressions) < 1 {
-		return false, errors.New(invalidRegoPolicyErrorMessage)
+		return result, errors.New(invalidRegoPolicyErrorMessage)
 	}
 
 	// Check the query evaluation result (if the `errors` output array has any items)
-	ers, ok := rs[0].Expressions[0].Value.([]any)
+	ers, ok := rs[0].Bindings["errors"].([]any)
 	if !ok {
-		return false, errors.New(invalidRegoPolicyErrorMessage)
+		return result, errors.New(invalidRegoPolicyErrorMessage)
 	}
-	if len(ers) > 0 {
-		return false, errors.New(strings.Join(u.SliceOfInterfacesToSliceOdStrings(ers), "\n"))
+	if result.Errors, err = parsePolicyViolations(ers); err != nil {
+		return result, errors.Wrap(err, invalidRegoPolicyErrorMessage)
 	}
 
-	return true, nil
+	warnings, _ := rs[0].Bindings["warnings"].([]any)
+	if result.Warnings, err = parsePolicyViolations(warnings); err != nil {
+		return result, errors.Wrap(err, invalidRegoPolicyErrorMessage)
+	}
+
+	if failOnSeverity, ok := rs[0].Bindings["fail_on_severity"].([]any); ok && len(failOnSeverity) > 0 {
+		if result.FailOnSeverity, ok = failOnSeverity[0].(string); !ok {
+			return result, errors.New(invalidRegoPolicyErrorMessage + ": 'fail_on_severity' must be a string")
+		}
+	}
+
+	return result, nil
 }
 
 // ValidateWithOpaLegacy validates the data structure using the provided OPA document
@@ -235,8 +276,226 @@ func ValidateWithOpaLegacy(
 	return true, nil
 }
 
-// ValidateWithCue validates the data structure using the provided CUE document
+// ValidateWithCue validates the data structure using the provided CUE document.
+// The data is unified with the CUE schema, and all the violations are returned with their CUE paths.
+// The CUE schema can import shared CUE packages from the module defined in the `cue.mod` folder in the CUE schemas base path
 // https://cuelang.org/docs/integrations/go/#processing-cue-in-go
-func ValidateWithCue(data any, schemaName string, schemaText string) (bool, error) {
-	return false, errors.New("validation using CUE is not supported yet")
+func ValidateWithCue(data any, schemaPath string, cueBasePath string) (bool, error) {
+	// Convert the data to JSON (which is valid CUE) to keep the integer and float numbers distinct
+	dataJson, err := u.ConvertToJSONFast(data)
+	if err != nil {
+		return false, err
+	}
+
+	schemaAbsPath, err := filepath.Abs(schemaPath)
+	if err != nil {
+		return false, err
+	}
+
+	loadConfig := &load.Config{
+		Dir: path.Dir(schemaAbsPath),
+	}
+
+	// Resolve the imports of the shared CUE packages from the CUE schemas base path
+	if cueBasePath != "" && u.FileOrDirExists(path.Join(cueBasePath, "cue.mod")) {
+		cueBaseAbsPath, err := filepath.Abs(cueBasePath)
+		if err != nil {
+			return false, err
+		}
+		loadConfig.ModuleRoot = cueBaseAbsPath
+	}
+
+	instances := load.Instances([]string{schemaAbsPath}, loadConfig)
+	if len(instances) == 0 {
+		return false, fmt.Errorf("invalid CUE schema in the file '%s'", schemaPath)
+	}
+	if instances[0].Err != nil {
+		return false, fmt.Errorf("invalid CUE schema in the file '%s'\n%v", schemaPath, instances[0].Err)
+	}
+
+	cueContext := cuecontext.New()
+
+	schemaValue := cueContext.BuildInstance(instances[0])
+	if schemaValue.Err() != nil {
+		return false, fmt.Errorf("invalid CUE schema in the file '%s'\n%v", schemaPath, schemaValue.Err())
+	}
+
+	dataValue := cueContext.CompileString(dataJson)
+	if dataValue.Err() != nil {
+		return false, dataValue.Err()
+	}
+
+	if err = schemaValue.Unify(dataValue).Validate(cue.Concrete(true)); err != nil {
+		var messages []string
+		for _, e := range cueErrors.Errors(err) {
+			format, args := e.Msg()
+			message := fmt.Sprintf(format, args...)
+			if len(e.Path()) > 0 {
+				message = fmt.Sprintf("%s: %s", strings.Join(e.Path(), "."), message)
+			}
+			messages = append(messages, message)
+		}
+		return false, errors.New(strings.Join(u.UniqueStrings(messages), "\n"))
+	}
+
+	return true, nil
+}
+
+// OPA policy severities, from the lowest to the highest
+var policySeverities = []string{"info", "low", "medium", "high", "critical"}
+
+// policySeverityRank returns the rank of the severity, or -1 if the severity is not supported
+func policySeverityRank(severity string) int {
+	for i, s := range policySeverities {
+		if s == severity {
+			return i
+		}
+	}
+	return -1
+}
+
+// parsePolicyViolations converts the errors or warnings returned by an OPA policy to policy violations.
+// Each item can be a string (the message) or an object with the `msg`, `path`, `severity` and `rule_id` attributes.
+// `path` can be a string in the `vars.name` format or an array of strings
+func parsePolicyViolations(items []any) ([]schema.PolicyViolation, error) {
+	var result []schema.PolicyViolation
+
+	for _, item := range items {
+		var violation schema.PolicyViolation
+
+		switch v := item.(type) {
+		case string:
+			violation.Message = v
+		case map[string]any:
+			violation.Message, _ = v["msg"].(string)
+			violation.Severity, _ = v["severity"].(string)
+			violation.RuleId, _ = v["rule_id"].(string)
+
+			switch p := v["path"].(type) {
+			case string:
+				violation.Path = p
+			case []any:
+				violation.Path = strings.Join(u.SliceOfInterfacesToSliceOdStrings(p), ".")
+			}
+
+			if violation.Message == "" {
+				return nil, fmt.Errorf("the policy violation '%v' does not have the 'msg' attribute", v)
+			}
+		default:
+			violation.Message = fmt.Sprintf("%v", v)
+		}
+
+		violation.Severity = strings.ToLower(violation.Severity)
+		if violation.Severity != "" && policySeverityRank(violation.Severity) < 0 {
+			return nil, fmt.Errorf("invalid severity '%s' in the policy violation '%s'. Supported severities: %s",
+				violation.Severity, violation.Message, strings.Join(policySeverities, ", "))
+		}
+
+		result = append(result, violation)
+	}
+
+	return result, nil
+}
+
+// applyPolicySeverityThreshold keeps the errors with the severity equal to or higher than the threshold,
+// and reports the errors with lower severity as warnings. The errors without severity always fail the validation.
+// The threshold from the `settings.validation` section overrides the threshold declared in the policy (`fail_on_severity`)
+func applyPolicySeverityThreshold(result schema.PolicyResult, failOnSeverity string) (schema.PolicyResult, error) {
+	threshold := strings.ToLower(failOnSeverity)
+	if threshold == "" {
+		threshold = strings.ToLower(result.FailOnSeverity)
+	}
+	if threshold == "" {
+		return result, nil
+	}
+
+	thresholdRank := policySeverityRank(threshold)
+	if thresholdRank < 0 {
+		return result, fmt.Errorf("invalid 'fail_on_severity' '%s'. Supported severities: %s", threshold, strings.Join(policySeverities, ", "))
+	}
+
+	var errs []schema.PolicyViolation
+	for _, e := range result.Errors {
+		if e.Severity == "" || policySeverityRank(e.Severity) >= thresholdRank {
+			errs = append(errs, e)
+		} else {
+			result.Warnings = append(result.Warnings, e)
+		}
+	}
+
+	result.Errors = errs
+	result.FailOnSeverity = threshold
+	return result, nil
+}
+
+// locatePolicyViolations finds the stack manifests and the lines where the values from the paths of the policy violations are defined.
+// The stack manifests are taken from the `sources` section of the component (the first stack dependency is where the final value is set)
+func locatePolicyViolations(cliConfig schema.CliConfiguration, componentSection any, violations []schema.PolicyViolation) {
+	componentSectionMap, ok := componentSection.(map[string]any)
+	if !ok {
+		return
+	}
+
+	sources, ok := componentSectionMap["sources"].(schema.ConfigSources)
+	if !ok {
+		return
+	}
+
+	stackManifestNodes := map[string]*yamlv3.Node{}
+
+	for i, v := range violations {
+		parts := strings.Split(v.Path, ".")
+		if len(parts) < 2 {
+			continue
+		}
+
+		item, ok := sources[parts[0]][parts[1]]
+		if !ok || len(item.StackDependencies) == 0 {
+			continue
+		}
+
+		dep := item.StackDependencies[0]
+		violations[i].StackFile = dep.StackFile
+
+		line, err := findConfigSourceLine(cliConfig, stackManifestNodes, dep, parts[1])
+		if err == nil {
+			violations[i].Line = line
+		}
+	}
+}
+
+// formatPolicyViolation formats the policy violation as `[rule_id] [severity] msg (path: vars.name, file: stack_file:line)`.
+// The violations returned as plain strings are formatted as the message only
+func formatPolicyViolation(v schema.PolicyViolation) string {
+	var sb strings.Builder
+
+	if v.RuleId != "" {
+		sb.WriteString("[" + v.RuleId + "] ")
+	}
+	if v.Severity != "" {
+		sb.WriteString("[" + v.Severity + "] ")
+	}
+	sb.WriteString(v.Message)
+
+	if v.Path != "" {
+		sb.WriteString(" (path: " + v.Path)
+		if v.StackFile != "" {
+			sb.WriteString(", file: " + v.StackFile)
+			if v.Line > 0 {
+				sb.WriteString(fmt.Sprintf(":%d", v.Line))
+			}
+		}
+		sb.WriteString(")")
+	}
+
+	return sb.String()
+}
+
+// formatPolicyViolations formats the policy violations, one per line
+func formatPolicyViolations(violations []schema.PolicyViolation) string {
+	lines := make([]string, 0, len(violations))
+	for _, v := range violations {
+		lines = append(lines, formatPolicyViolation(v))
+	}
+	return strings.Join(lines, "\n")
 }
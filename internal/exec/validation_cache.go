@@ -0,0 +1,221 @@
+package exec
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/cloudposse/atmos/pkg/schema"
+	u "github.com/cloudposse/atmos/pkg/utils"
+)
+
+// validationCacheVersion is part of the cache key. Change it when the validation logic changes to invalidate the previous cache entries
+const validationCacheVersion = "1"
+
+// validationCacheEntry is a cached result of a successful validation of a component
+type validationCacheEntry struct {
+	SchemaPath string                   `json:"schema_path"`
+	SchemaType string                   `json:"schema_type"`
+	Warnings   []schema.PolicyViolation `json:"warnings,omitempty"`
+	CreatedAt  time.Time                `json:"created_at"`
+}
+
+// validateComponentWithCache validates the component config using the schema document, and caches the result.
+// The cache key is a hash of the component section, the schema file, and the OPA modules (or CUE files) that the schema can use.
+// If the component config and the policies did not change since the last successful validation, the policies are not evaluated again.
+// Only the successful results are cached, so the failed validations are always re-evaluated and report the current errors
+func validateComponentWithCache(
+	cliConfig schema.CliConfiguration,
+	componentSection any,
+	schemaPath string,
+	schemaType string,
+	modulePaths []string,
+	timeoutSeconds int,
+	failOnSeverity string,
+) (bool, schema.PolicyResult, error) {
+	if cliConfig.Validate.Cache.Disabled {
+		return validateComponentInternal(cliConfig, componentSection, schemaPath, schemaType, modulePaths, timeoutSeconds, failOnSeverity)
+	}
+
+	cacheFile, err := validationCacheFile(cliConfig, componentSection, schemaPath, schemaType, modulePaths, failOnSeverity)
+	if err != nil {
+		// The cache is an optimization, any errors (e.g. a missing schema file) are reported by the validation itself
+		u.LogDebug(cliConfig, fmt.Sprintf("Not using the validation cache: %v", err))
+		return validateComponentInternal(cliConfig, componentSection, schemaPath, schemaType, modulePaths, timeoutSeconds, failOnSeverity)
+	}
+
+	if entry, ok := readValidationCacheEntry(cacheFile); ok {
+		u.LogDebug(cliConfig, fmt.Sprintf("Using the cached validation result for the '%s' file '%s'", schemaType, schemaPath))
+		return true, schema.PolicyResult{Warnings: entry.Warnings}, nil
+	}
+
+	ok, result, err := validateComponentInternal(cliConfig, componentSection, schemaPath, schemaType, modulePaths, timeoutSeconds, failOnSeverity)
+	if err != nil || !ok {
+		return ok, result, err
+	}
+
+	entry := validationCacheEntry{
+		SchemaPath: schemaPath,
+		SchemaType: schemaType,
+		Warnings:   result.Warnings,
+		CreatedAt:  time.Now().UTC(),
+	}
+
+	if err = writeValidationCacheEntry(cacheFile, entry); err != nil {
+		u.LogDebug(cliConfig, fmt.Sprintf("Failed to write the validation cache file '%s': %v", cacheFile, err))
+	}
+
+	return ok, result, nil
+}
+
+// validationCacheDir returns the folder with the validation cache files.
+// It can be configured in the `validate.cache.path` section in `atmos.yaml` (absolute path or a path relative to `base_path`),
+// and defaults to `atmos/validation` in the user cache folder
+func validationCacheDir(cliConfig schema.CliConfiguration) (string, error) {
+	if cliConfig.Validate.Cache.Path != "" {
+		if filepath.IsAbs(cliConfig.Validate.Cache.Path) {
+			return cliConfig.Validate.Cache.Path, nil
+		}
+		return path.Join(cliConfig.BasePath, cliConfig.Validate.Cache.Path), nil
+	}
+
+	userCacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	return path.Join(userCacheDir, "atmos", "validation"), nil
+}
+
+// validationCacheFile returns the path to the cache file for the validation of the component using the schema
+func validationCacheFile(
+	cliConfig schema.CliConfiguration,
+	componentSection any,
+	schemaPath string,
+	schemaType string,
+	modulePaths []string,
+	failOnSeverity string,
+) (string, error) {
+	cacheDir, err := validationCacheDir(cliConfig)
+	if err != nil {
+		return "", err
+	}
+
+	key, err := validationCacheKey(cliConfig, componentSection, schemaPath, schemaType, modulePaths, failOnSeverity)
+	if err != nil {
+		return "", err
+	}
+
+	return path.Join(cacheDir, key+".json"), nil
+}
+
+// validationCacheKey returns a hash of the component section, the schema file, and the files that the schema can use
+// (the OPA modules in the module paths, or the CUE files in the CUE schemas base path)
+func validationCacheKey(
+	cliConfig schema.CliConfiguration,
+	componentSection any,
+	schemaPath string,
+	schemaType string,
+	modulePaths []string,
+	failOnSeverity string,
+) (string, error) {
+	h := sha256.New()
+
+	_, _ = fmt.Fprintf(h, "version:%s\ntype:%s\nfail_on_severity:%s\n", validationCacheVersion, schemaType, failOnSeverity)
+
+	// The YAML encoder sorts the map keys, so the same component section always produces the same hash
+	componentSectionYaml, err := yaml.Marshal(componentSection)
+	if err != nil {
+		return "", err
+	}
+	_, _ = h.Write(componentSectionYaml)
+
+	schemaFile, err := findValidationSchemaFile(cliConfig, schemaPath, schemaType)
+	if err != nil {
+		return "", err
+	}
+	if err = hashValidationCachePath(h, schemaFile); err != nil {
+		return "", err
+	}
+
+	switch schemaType {
+	case "opa":
+		modulePathsAbsolute, err := u.JoinAbsolutePathWithPaths(path.Join(cliConfig.BasePath, cliConfig.Schemas.Opa.BasePath), modulePaths)
+		if err != nil {
+			return "", err
+		}
+		for _, p := range modulePathsAbsolute {
+			if err = hashValidationCachePath(h, p); err != nil {
+				return "", err
+			}
+		}
+	case "cue":
+		if err = hashValidationCachePath(h, path.Join(cliConfig.BasePath, cliConfig.Schemas.Cue.BasePath)); err != nil {
+			return "", err
+		}
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// hashValidationCachePath writes the names and the content of the file, or all the files in the folder and its subfolders, to the hash
+func hashValidationCachePath(h hash.Hash, p string) error {
+	return filepath.WalkDir(p, func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		f, err := os.Open(filePath)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		_, _ = fmt.Fprintf(h, "\nfile:%s\n", filePath)
+		_, err = io.Copy(h, f)
+		return err
+	})
+}
+
+// readValidationCacheEntry reads the cache entry from the file. It returns `false` if the file does not exist or is invalid
+func readValidationCacheEntry(cacheFile string) (validationCacheEntry, bool) {
+	var entry validationCacheEntry
+
+	content, err := os.ReadFile(cacheFile)
+	if err != nil {
+		return entry, false
+	}
+
+	if err = json.Unmarshal(content, &entry); err != nil {
+		return entry, false
+	}
+
+	return entry, true
+}
+
+// writeValidationCacheEntry writes the cache entry to the file
+func writeValidationCacheEntry(cacheFile string, entry validationCacheEntry) error {
+	if err := u.EnsureDir(cacheFile); err != nil {
+		return err
+	}
+
+	content, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(cacheFile, content, 0644)
+}
@@ -1,9 +1,15 @@
 package atlantis
 
 import (
+	"encoding/json"
+	"os"
+	"path"
 	"testing"
 
+	"github.com/mitchellh/mapstructure"
+	cp "github.com/otiai10/copy"
 	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
 
 	e "github.com/cloudposse/atmos/internal/exec"
 	cfg "github.com/cloudposse/atmos/pkg/config"
@@ -52,6 +58,7 @@ func TestExecuteAtlantisGenerateRepoConfig(t *testing.T) {
 		"project-1",
 		nil,
 		nil,
+		false,
 	)
 
 	assert.Nil(t, err)
@@ -68,6 +75,7 @@ func TestExecuteAtlantisGenerateRepoConfig2(t *testing.T) {
 		"",
 		nil,
 		nil,
+		false,
 	)
 
 	assert.Nil(t, err)
@@ -88,7 +96,302 @@ func TestExecuteAtlantisGenerateRepoConfigAffectedOnly(t *testing.T) {
 		"",
 		"",
 		true,
+		false,
 	)
 
 	assert.Nil(t, err)
 }
+
+func TestExecuteAtlantisGenerateRepoConfigDependsOn(t *testing.T) {
+	cliConfig, err := cfg.InitCliConfig(schema.ConfigAndStacksInfo{}, true)
+	assert.Nil(t, err)
+
+	outputPath := path.Join(t.TempDir(), "atlantis.yaml")
+
+	err = e.ExecuteAtlantisGenerateRepoConfig(
+		cliConfig,
+		outputPath,
+		"config-1",
+		"project-1",
+		nil,
+		nil,
+		false,
+	)
+	assert.Nil(t, err)
+
+	content, err := os.ReadFile(outputPath)
+	assert.Nil(t, err)
+
+	var atlantisConfig schema.AtlantisConfigOutput
+	err = yaml.Unmarshal(content, &atlantisConfig)
+	assert.Nil(t, err)
+
+	projects := map[string]schema.AtlantisProjectConfig{}
+	for _, p := range atlantisConfig.Projects {
+		projects[p.Name] = p
+	}
+
+	// `settings.depends_on` is translated into the Atlantis project names
+	project := projects["tenant1-ue2-dev-top-level-component1"]
+	assert.Equal(t, []string{"tenant1-ue2-dev-test-test-component", "tenant1-ue2-dev-test-test-component-override"}, project.DependsOn)
+	assert.Equal(t, 1, project.ExecutionOrderGroup)
+
+	// The dependencies are applied first
+	assert.Equal(t, 0, projects["tenant1-ue2-dev-test-test-component"].ExecutionOrderGroup)
+	assert.Empty(t, projects["tenant1-ue2-dev-test-test-component"].DependsOn)
+}
+
+func TestAtlantisProjectTemplateRoundTrip(t *testing.T) {
+	template := `
+name: "{tenant}-{environment}-{stage}-{component}"
+branch: "/main/"
+workspace: "{workspace}"
+workflow: workflow-1
+dir: "{component-path}"
+terraform_version: v1.5
+terraform_distribution: opentofu
+delete_source_branch_on_merge: true
+autoplan:
+  enabled: true
+  when_modified:
+    - "**/*.tf"
+plan_requirements:
+  - undiverged
+apply_requirements:
+  - approved
+import_requirements:
+  - approved
+repo_locking: false
+repo_locks:
+  mode: on_apply
+custom_policy_check: true
+policy_check: true
+silence_pr_comments:
+  - apply
+depends_on:
+  - "{tenant}-{environment}-{stage}-vpc"
+execution_order_group: 1
+new_atlantis_field:
+  key: value
+`
+	var templateMap map[any]any
+	err := yaml.Unmarshal([]byte(template), &templateMap)
+	assert.Nil(t, err)
+
+	var projectTemplate schema.AtlantisProjectConfig
+	err = mapstructure.Decode(templateMap, &projectTemplate)
+	assert.Nil(t, err)
+
+	out, err := yaml.Marshal(projectTemplate)
+	assert.Nil(t, err)
+
+	var outMap map[any]any
+	err = yaml.Unmarshal(out, &outMap)
+	assert.Nil(t, err)
+
+	// Nothing in the template is lost
+	assert.Equal(t, templateMap, outMap)
+}
+
+func TestAtlantisConfigTemplateRoundTrip(t *testing.T) {
+	template := `
+version: 3
+automerge: true
+autodiscover:
+  mode: disabled
+  ignore_paths:
+    - modules/**
+delete_source_branch_on_merge: true
+parallel_plan: true
+parallel_apply: true
+abort_on_execution_order_fail: true
+allowed_regexp_prefixes:
+  - dev/
+allowed_overrides:
+  - workflow
+allowed_workflows:
+  - workflow-1
+allow_custom_workflows: true
+policies:
+  conftest_version: v0.46.0
+  owners:
+    users:
+      - admin
+    teams:
+      - platform
+  policy_sets:
+    - name: atmos
+      path: policies/atmos
+      source: local
+      owners:
+        users:
+          - admin
+      prevent_self_review: true
+new_atlantis_repo_field: true
+`
+	var templateMap map[any]any
+	err := yaml.Unmarshal([]byte(template), &templateMap)
+	assert.Nil(t, err)
+
+	var configTemplate schema.AtlantisRepoConfig
+	err = mapstructure.Decode(templateMap, &configTemplate)
+	assert.Nil(t, err)
+
+	out, err := yaml.Marshal(configTemplate)
+	assert.Nil(t, err)
+
+	var outMap map[any]any
+	err = yaml.Unmarshal(out, &outMap)
+	assert.Nil(t, err)
+
+	// Nothing in the template is lost
+	assert.Equal(t, templateMap, outMap)
+}
+
+func TestExecuteAtlantisGenerateRepoConfigWithAllFields(t *testing.T) {
+	cliConfig, err := cfg.InitCliConfig(schema.ConfigAndStacksInfo{}, true)
+	assert.Nil(t, err)
+
+	outputPath := path.Join(t.TempDir(), "atlantis.yaml")
+
+	err = e.ExecuteAtlantisGenerateRepoConfig(
+		cliConfig,
+		outputPath,
+		"config-2",
+		"project-2",
+		[]string{"tenant1-ue2-dev"},
+		[]string{"infra/vpc"},
+		false,
+	)
+	assert.Nil(t, err)
+
+	content, err := os.ReadFile(outputPath)
+	assert.Nil(t, err)
+
+	var atlantisConfig map[string]any
+	err = yaml.Unmarshal(content, &atlantisConfig)
+	assert.Nil(t, err)
+
+	assert.Equal(t, true, atlantisConfig["abort_on_execution_order_fail"])
+	assert.Equal(t, true, atlantisConfig["new_atlantis_repo_field"])
+	assert.Equal(t, []any{"apply_requirements", "workflow"}, atlantisConfig["allowed_overrides"])
+	assert.NotNil(t, atlantisConfig["policies"])
+
+	projects := atlantisConfig["projects"].([]any)
+	assert.Equal(t, 1, len(projects))
+
+	project := projects[0].(map[any]any)
+	assert.Equal(t, "tenant1-ue2-dev-infra-vpc", project["name"])
+	assert.Equal(t, "/main/", project["branch"])
+	assert.Equal(t, "opentofu", project["terraform_distribution"])
+	assert.Equal(t, []any{"undiverged"}, project["plan_requirements"])
+	assert.Equal(t, []any{"approved"}, project["import_requirements"])
+	assert.Equal(t, map[any]any{"mode": "on_apply"}, project["repo_locks"])
+	assert.Equal(t, true, project["custom_policy_check"])
+	assert.Equal(t, []any{"apply"}, project["silence_pr_comments"])
+	assert.Equal(t, 2, project["execution_order_group"])
+	assert.Equal(t, "value", project["new_atlantis_field"])
+}
+
+func TestExecuteAtlantisGenerateRepoConfigWhenModified(t *testing.T) {
+	cliConfig, err := cfg.InitCliConfig(schema.ConfigAndStacksInfo{}, true)
+	assert.Nil(t, err)
+
+	outputPath := path.Join(t.TempDir(), "atlantis.yaml")
+
+	err = e.ExecuteAtlantisGenerateRepoConfig(
+		cliConfig,
+		outputPath,
+		"config-1",
+		"project-1",
+		[]string{"tenant1-ue2-dev"},
+		[]string{"top-level-component1"},
+		false,
+	)
+	assert.Nil(t, err)
+
+	content, err := os.ReadFile(outputPath)
+	assert.Nil(t, err)
+
+	var atlantisConfig schema.AtlantisConfigOutput
+	err = yaml.Unmarshal(content, &atlantisConfig)
+	assert.Nil(t, err)
+
+	assert.Equal(t, 1, len(atlantisConfig.Projects))
+	whenModified := atlantisConfig.Projects[0].Autoplan.WhenModified
+
+	// The patterns from the project template
+	assert.Contains(t, whenModified, "**/*.tf")
+	assert.Contains(t, whenModified, "varfiles/$PROJECT_NAME.tfvars.json")
+	// The component folder
+	assert.Contains(t, whenModified, "**/*.tf*")
+	// The local module
+	assert.Contains(t, whenModified, "../../../modules/label/**/*.tf*")
+	// The stack manifest and its imports
+	assert.Contains(t, whenModified, "../../../stacks/orgs/cp/tenant1/dev/us-east-2.yaml")
+	assert.Contains(t, whenModified, "../../../stacks/catalog/terraform/top-level-component1.yaml")
+	assert.Contains(t, whenModified, "../../../stacks/mixins/stage/dev.yaml")
+}
+
+func TestExecuteAtlantisGenerateRepoConfigWithFiles(t *testing.T) {
+	// Generate the files in a copy of the fixtures
+	basePath := t.TempDir()
+	err := cp.Copy("../../examples/tests", basePath)
+	assert.Nil(t, err)
+	t.Setenv("ATMOS_BASE_PATH", basePath)
+
+	cliConfig, err := cfg.InitCliConfig(schema.ConfigAndStacksInfo{}, true)
+	assert.Nil(t, err)
+
+	err = e.ExecuteAtlantisGenerateRepoConfig(
+		cliConfig,
+		path.Join(basePath, "atlantis.yaml"),
+		"config-1",
+		"project-1",
+		[]string{"tenant1-ue2-dev"},
+		[]string{"top-level-component1"},
+		true,
+	)
+	assert.Nil(t, err)
+
+	componentPath := path.Join(basePath, "components/terraform/top-level-component1")
+
+	varFile, err := os.ReadFile(path.Join(componentPath, "tenant1-ue2-dev-top-level-component1.terraform.tfvars.json"))
+	assert.Nil(t, err)
+	assert.Contains(t, string(varFile), `"stage": "dev"`)
+
+	// The backend config of each project is written to its own file, and the shared `backend.tf.json` declares only the backend type
+	backendConfigFile, err := os.ReadFile(path.Join(componentPath, "tenant1-ue2-dev-top-level-component1.backend.tfbackend"))
+	assert.Nil(t, err)
+	assert.Contains(t, string(backendConfigFile), `bucket = "cp-ue2-root-tfstate"`)
+	assert.Contains(t, string(backendConfigFile), `workspace_key_prefix = "top-level-component1"`)
+
+	backendFile, err := os.ReadFile(path.Join(componentPath, "backend.tf.json"))
+	assert.Nil(t, err)
+	var backendConfig map[string]any
+	err = json.Unmarshal(backendFile, &backendConfig)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]any{"terraform": map[string]any{"backend": map[string]any{"s3": map[string]any{}}}}, backendConfig)
+
+	// The projects for the same terraform component with different backend configs get their own backend config files
+	err = e.ExecuteAtlantisGenerateRepoConfig(
+		cliConfig,
+		path.Join(basePath, "atlantis.yaml"),
+		"config-1",
+		"project-1",
+		[]string{"tenant1-ue2-dev"},
+		[]string{"infra/vpc", "vpc"},
+		true,
+	)
+	assert.Nil(t, err)
+
+	vpcComponentPath := path.Join(basePath, "components/terraform/infra/vpc")
+
+	infraVpcBackendConfigFile, err := os.ReadFile(path.Join(vpcComponentPath, "tenant1-ue2-dev-infra-vpc.backend.tfbackend"))
+	assert.Nil(t, err)
+
+	vpcBackendConfigFile, err := os.ReadFile(path.Join(vpcComponentPath, "tenant1-ue2-dev-vpc.backend.tfbackend"))
+	assert.Nil(t, err)
+
+	assert.NotEqual(t, string(infraVpcBackendConfigFile), string(vpcBackendConfigFile))
+}
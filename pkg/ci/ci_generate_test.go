@@ -0,0 +1,101 @@
+package ci
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	e "github.com/cloudposse/atmos/internal/exec"
+	cfg "github.com/cloudposse/atmos/pkg/config"
+	"github.com/cloudposse/atmos/pkg/schema"
+	u "github.com/cloudposse/atmos/pkg/utils"
+)
+
+func TestCIGenerateGitLab(t *testing.T) {
+	cliConfig, err := cfg.InitCliConfig(schema.ConfigAndStacksInfo{}, true)
+	assert.Nil(t, err)
+
+	pipeline, err := e.ExecuteCIGenerate(
+		cliConfig,
+		"gitlab",
+		"pipeline-1",
+		"job-1",
+		[]string{"tenant1-ue2-dev"},
+		[]string{"top-level-component1", "test/test-component-override"},
+		nil,
+	)
+	assert.Nil(t, err)
+
+	err = u.PrintAsYAML(pipeline)
+	assert.Nil(t, err)
+
+	assert.Equal(t, []string{"plan", "apply"}, pipeline["stages"])
+	assert.NotNil(t, pipeline["default"])
+
+	planJob, ok := pipeline["plan-tenant1-ue2-dev-top-level-component1"].(map[string]any)
+	assert.True(t, ok)
+	assert.Equal(t, "plan", planJob["stage"])
+	assert.Equal(t, []any{"atmos terraform plan $ATMOS_COMPONENT -s $ATMOS_STACK"}, planJob["script"])
+	assert.Equal(t, "tenant1-ue2-dev-top-level-component1", planJob["resource_group"])
+	assert.Equal(t, map[string]any{"ATMOS_COMPONENT": "top-level-component1", "ATMOS_STACK": "tenant1-ue2-dev"}, planJob["variables"])
+	assert.Nil(t, planJob["needs"])
+
+	applyJob, ok := pipeline["apply-tenant1-ue2-dev-top-level-component1"].(map[string]any)
+	assert.True(t, ok)
+	assert.Equal(t, "apply", applyJob["stage"])
+	assert.Equal(t, "manual", applyJob["when"])
+	// The apply job depends on the plan job, and on the apply jobs of the components from `settings.depends_on` in the pipeline
+	assert.Equal(t, []any{
+		"plan-tenant1-ue2-dev-top-level-component1",
+		"apply-tenant1-ue2-dev-test-test-component-override",
+	}, applyJob["needs"])
+
+	_, ok = pipeline["apply-tenant1-ue2-dev-test-test-component-override"].(map[string]any)
+	assert.True(t, ok)
+}
+
+func TestCIGenerateBuildkite(t *testing.T) {
+	cliConfig, err := cfg.InitCliConfig(schema.ConfigAndStacksInfo{}, true)
+	assert.Nil(t, err)
+
+	pipeline, err := e.ExecuteCIGenerate(
+		cliConfig,
+		"buildkite",
+		"pipeline-1",
+		"job-1",
+		[]string{"tenant1-ue2-dev"},
+		[]string{"top-level-component1"},
+		nil,
+	)
+	assert.Nil(t, err)
+
+	err = u.PrintAsYAML(pipeline)
+	assert.Nil(t, err)
+
+	assert.Equal(t, map[string]any{"queue": "terraform"}, pipeline["agents"])
+
+	steps, ok := pipeline["steps"].([]any)
+	assert.True(t, ok)
+	// The plan step, the block step for the manual apply, and the apply step
+	assert.Equal(t, 3, len(steps))
+
+	planStep := steps[0].(map[string]any)
+	assert.Equal(t, "plan-tenant1-ue2-dev-top-level-component1", planStep["key"])
+	assert.Equal(t, ":terraform: plan top-level-component1 in tenant1-ue2-dev", planStep["label"])
+
+	blockStep := steps[1].(map[string]any)
+	assert.Equal(t, "approve-tenant1-ue2-dev-top-level-component1", blockStep["key"])
+	assert.Equal(t, []string{"plan-tenant1-ue2-dev-top-level-component1"}, blockStep["depends_on"])
+
+	applyStep := steps[2].(map[string]any)
+	assert.Equal(t, "apply-tenant1-ue2-dev-top-level-component1", applyStep["key"])
+	assert.Equal(t, []any{"approve-tenant1-ue2-dev-top-level-component1"}, applyStep["depends_on"])
+}
+
+func TestCIGenerateInvalidProvider(t *testing.T) {
+	cliConfig, err := cfg.InitCliConfig(schema.ConfigAndStacksInfo{}, true)
+	assert.Nil(t, err)
+
+	_, err = e.ExecuteCIGenerate(cliConfig, "jenkins", "", "job-1", nil, nil, nil)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,31 @@
+package config
+
+import (
+	_ "embed"
+	"os"
+)
+
+// AtmosManifestJsonSchema is the JSON Schema of the Atmos stack manifests bundled with Atmos.
+// It's the same schema as published on https://atmos.tools/schemas/atmos/atmos-manifest/1.0/atmos-manifest.json,
+// and it's used to validate the stack manifests if `schemas.atmos.manifest` is not configured in `atmos.yaml`
+//
+//go:embed schemas/atmos-manifest.json
+var AtmosManifestJsonSchema string
+
+// EmbeddedAtmosManifestJsonSchemaPath is the path to the Atmos manifest JSON Schema that points to the schema bundled with Atmos
+const EmbeddedAtmosManifestJsonSchemaPath = "embedded://atmos-manifest.json"
+
+// GetAtmosManifestJsonSchema returns the Atmos manifest JSON Schema from the file, or the bundled schema if the path is
+// `EmbeddedAtmosManifestJsonSchemaPath`
+func GetAtmosManifestJsonSchema(atmosManifestJsonSchemaFilePath string) (string, error) {
+	if atmosManifestJsonSchemaFilePath == EmbeddedAtmosManifestJsonSchemaPath {
+		return AtmosManifestJsonSchema, nil
+	}
+
+	content, err := os.ReadFile(atmosManifestJsonSchemaFilePath)
+	if err != nil {
+		return "", err
+	}
+
+	return string(content), nil
+}
@@ -0,0 +1,284 @@
+package config
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/santhosh-tekuri/jsonschema/v5"
+	yamlv3 "gopkg.in/yaml.v3"
+
+	u "github.com/cloudposse/atmos/pkg/utils"
+)
+
+// CliConfigJsonSchema is the JSON Schema of the Atmos CLI config files (`atmos.yaml`) bundled with Atmos
+//
+//go:embed schemas/atmos-cli-config.json
+var CliConfigJsonSchema string
+
+const cliConfigJsonSchemaName = "atmos-cli-config.json"
+
+// CliConfigError is an invalid or unknown key in an Atmos CLI config file
+type CliConfigError struct {
+	// File is the path to the CLI config file that contains the key
+	File   string
+	Line   int
+	Column int
+	Err    error
+}
+
+// Error returns the error message in the `file:line:col: error` format
+func (e *CliConfigError) Error() string {
+	var sb strings.Builder
+
+	sb.WriteString(e.File)
+	if e.Line > 0 {
+		sb.WriteString(":" + strconv.Itoa(e.Line))
+		if e.Column > 0 {
+			sb.WriteString(":" + strconv.Itoa(e.Column))
+		}
+	}
+	sb.WriteString(": ")
+	sb.WriteString(e.Err.Error())
+
+	return sb.String()
+}
+
+// Unwrap returns the original error
+func (e *CliConfigError) Unwrap() error {
+	return e.Err
+}
+
+// ValidateCliConfigFiles validates each Atmos CLI config file (layer) using the bundled JSON Schema,
+// and returns an error for each unknown (e.g. misspelled) key and each invalid value, with the file and position of the key
+func ValidateCliConfigFiles(files []string) ([]error, error) {
+	var rootSchema map[string]any
+	if err := json.Unmarshal([]byte(CliConfigJsonSchema), &rootSchema); err != nil {
+		return nil, err
+	}
+
+	compiler := jsonschema.NewCompiler()
+	compiler.Draft = jsonschema.Draft2020
+	if err := compiler.AddResource(cliConfigJsonSchemaName, strings.NewReader(CliConfigJsonSchema)); err != nil {
+		return nil, err
+	}
+
+	compiledSchema, err := compiler.Compile(cliConfigJsonSchemaName)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []error
+
+	for _, file := range files {
+		errs, err := validateCliConfigFile(file, rootSchema, compiledSchema)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, errs...)
+	}
+
+	return result, nil
+}
+
+// validateCliConfigFile validates the CLI config file
+func validateCliConfigFile(file string, rootSchema map[string]any, compiledSchema *jsonschema.Schema) ([]error, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := u.ParseYAMLNode(string(content))
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid CLI config file '%s'", file)
+	}
+
+	// Empty config file
+	if len(root.Content) == 0 {
+		return nil, nil
+	}
+
+	var result []*CliConfigError
+
+	// Unknown keys
+	result = append(result, findUnknownCliConfigKeys(file, root.Content[0], rootSchema, rootSchema, nil)...)
+
+	// Invalid values
+	var data any
+	if err = root.Decode(&data); err != nil {
+		return nil, errors.Wrapf(err, "invalid CLI config file '%s'", file)
+	}
+
+	// Convert the data to JSON and back to Go map to get the JSON data types
+	dataJson, err := u.ConvertToJSONFast(data)
+	if err != nil {
+		return nil, err
+	}
+
+	dataFromJson, err := u.ConvertFromJSON(dataJson)
+	if err != nil {
+		return nil, err
+	}
+
+	err = compiledSchema.Validate(dataFromJson)
+	if err != nil {
+		var validationError *jsonschema.ValidationError
+		if !errors.As(err, &validationError) {
+			return nil, err
+		}
+		result = append(result, cliConfigValidationErrors(file, root, validationError)...)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Line != result[j].Line {
+			return result[i].Line < result[j].Line
+		}
+		return result[i].Column < result[j].Column
+	})
+
+	errs := make([]error, 0, len(result))
+	for _, e := range result {
+		errs = append(errs, e)
+	}
+
+	return errs, nil
+}
+
+// findUnknownCliConfigKeys walks the YAML node tree together with the JSON Schema, and returns an error (with a "did you mean" suggestion)
+// for each key that is not allowed by the schema
+func findUnknownCliConfigKeys(
+	file string,
+	node *yamlv3.Node,
+	s map[string]any,
+	rootSchema map[string]any,
+	keyPath []string,
+) []*CliConfigError {
+	s = resolveCliConfigJsonSchemaRef(s, rootSchema)
+
+	if node.Kind == yamlv3.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+
+	var result []*CliConfigError
+
+	switch node.Kind {
+	case yamlv3.MappingNode:
+		properties, _ := s["properties"].(map[string]any)
+
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i].Value
+			value := node.Content[i+1]
+			childPath := append(append([]string{}, keyPath...), key)
+
+			if propertySchema, ok := properties[key].(map[string]any); ok {
+				result = append(result, findUnknownCliConfigKeys(file, value, propertySchema, rootSchema, childPath)...)
+				continue
+			}
+
+			switch additionalProperties := s["additionalProperties"].(type) {
+			case map[string]any:
+				result = append(result, findUnknownCliConfigKeys(file, value, additionalProperties, rootSchema, childPath)...)
+			case bool:
+				if additionalProperties {
+					continue
+				}
+
+				var errorMessage string
+				if len(keyPath) == 0 {
+					errorMessage = fmt.Sprintf("unknown key '%s'", key)
+				} else {
+					errorMessage = fmt.Sprintf("unknown key '%s' in the '%s' section", key, strings.Join(keyPath, "."))
+				}
+
+				if suggestion := u.FindClosestString(key, u.StringKeysFromMap(properties), 2); suggestion != "" {
+					errorMessage += fmt.Sprintf(", did you mean '%s'?", suggestion)
+				}
+
+				result = append(result, &CliConfigError{
+					File:   file,
+					Line:   node.Content[i].Line,
+					Column: node.Content[i].Column,
+					Err:    errors.New(errorMessage),
+				})
+			}
+		}
+
+	case yamlv3.SequenceNode:
+		if items, ok := s["items"].(map[string]any); ok {
+			for i, item := range node.Content {
+				childPath := append(append([]string{}, keyPath...), strconv.Itoa(i))
+				result = append(result, findUnknownCliConfigKeys(file, item, items, rootSchema, childPath)...)
+			}
+		}
+	}
+
+	return result
+}
+
+// resolveCliConfigJsonSchemaRef returns the schema from the `$defs` section if the schema is a reference (`$ref: "#/$defs/<name>"`)
+func resolveCliConfigJsonSchemaRef(s map[string]any, rootSchema map[string]any) map[string]any {
+	ref, ok := s["$ref"].(string)
+	if !ok || !strings.HasPrefix(ref, "#/$defs/") {
+		return s
+	}
+
+	defs, _ := rootSchema["$defs"].(map[string]any)
+	if def, ok := defs[strings.TrimPrefix(ref, "#/$defs/")].(map[string]any); ok {
+		return def
+	}
+
+	return s
+}
+
+// cliConfigValidationErrors converts the JSON Schema validation errors to the CLI config errors.
+// The unknown keys (`additionalProperties`) are skipped since they are reported by `findUnknownCliConfigKeys`
+func cliConfigValidationErrors(file string, root *yamlv3.Node, e *jsonschema.ValidationError) []*CliConfigError {
+	if len(e.Causes) > 0 {
+		var result []*CliConfigError
+		for _, c := range e.Causes {
+			result = append(result, cliConfigValidationErrors(file, root, c)...)
+		}
+		return result
+	}
+
+	if strings.HasSuffix(e.KeywordLocation, "/additionalProperties") {
+		return nil
+	}
+
+	var keyPath []string
+	for _, p := range strings.Split(strings.TrimPrefix(e.InstanceLocation, "/"), "/") {
+		if p != "" {
+			keyPath = append(keyPath, strings.ReplaceAll(strings.ReplaceAll(p, "~1", "/"), "~0", "~"))
+		}
+	}
+
+	var err error
+	if len(keyPath) == 0 {
+		err = errors.New(e.Message)
+	} else {
+		err = fmt.Errorf("invalid '%s': %s", strings.Join(keyPath, "."), e.Message)
+	}
+
+	line, column := u.FindYAMLKeyPosition(root, keyPath)
+
+	return []*CliConfigError{{
+		File:   file,
+		Line:   line,
+		Column: column,
+		Err:    err,
+	}}
+}
+
+// newCliConfigValidationError combines the CLI config errors into one error
+func newCliConfigValidationError(errs []error) error {
+	messages := make([]string, 0, len(errs))
+	for _, e := range errs {
+		messages = append(messages, e.Error())
+	}
+	return fmt.Errorf("invalid CLI config:\n%s", strings.Join(messages, "\n"))
+}
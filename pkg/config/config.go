@@ -12,6 +12,7 @@ import (
 	"path"
 	"path/filepath"
 	"runtime"
+	"strconv"
 )
 
 var NotFound = errors.New("\n'atmos.yaml' CLI config files not found in any of the searched paths: system dir, home dir, current dir, ENV vars." +
@@ -26,13 +27,12 @@ func InitCliConfig(configAndStacksInfo schema.ConfigAndStacksInfo, processStacks
 	// system dir (`/usr/local/etc/atmos` on Linux, `%LOCALAPPDATA%/atmos` on Windows)
 	// home dir (~/.atmos)
 	// current directory
+	// the file specified in the `--config` command-line flag
 	// ENV vars
 	// Command-line arguments
 
 	var cliConfig schema.CliConfiguration
 	var err error
-	configFound := false
-	var found bool
 
 	v := viper.New()
 	v.SetConfigType("yaml")
@@ -41,101 +41,52 @@ func InitCliConfig(configAndStacksInfo schema.ConfigAndStacksInfo, processStacks
 	// Default configuration values
 	v.SetDefault("components.helmfile.use_eks", true)
 
-	// Process config in system folder
-	configFilePath1 := ""
+	configFiles, err := FindCliConfigFiles(configAndStacksInfo)
+	if err != nil {
+		return cliConfig, err
+	}
 
-	// https://pureinfotech.com/list-environment-variables-windows-10/
-	// https://docs.microsoft.com/en-us/windows/deployment/usmt/usmt-recognized-environment-variables
-	// https://softwareengineering.stackexchange.com/questions/299869/where-is-the-appropriate-place-to-put-application-configuration-files-for-each-p
-	// https://stackoverflow.com/questions/37946282/why-does-appdata-in-windows-7-seemingly-points-to-wrong-folder
-	if runtime.GOOS == "windows" {
-		appDataDir := os.Getenv(WindowsAppDataEnvVar)
-		if len(appDataDir) > 0 {
-			configFilePath1 = appDataDir
-		}
-	} else {
-		configFilePath1 = SystemDirConfigFilePath
+	if len(configFiles) == 0 {
+		return cliConfig, NotFound
 	}
 
-	if len(configFilePath1) > 0 {
-		configFile1 := path.Join(configFilePath1, CliConfigFileName)
-		found, err = processConfigFile(cliConfig, configFile1, v)
+	for _, configFile := range configFiles {
+		_, err = processConfigFile(cliConfig, configFile, v)
 		if err != nil {
 			return cliConfig, err
 		}
-		if found {
-			configFound = true
-		}
-	}
-
-	// Process config in user's HOME dir
-	configFilePath2, err := homedir.Dir()
-	if err != nil {
-		return cliConfig, err
-	}
-	configFile2 := path.Join(configFilePath2, ".atmos", CliConfigFileName)
-	found, err = processConfigFile(cliConfig, configFile2, v)
-	if err != nil {
-		return cliConfig, err
-	}
-	if found {
-		configFound = true
 	}
 
-	// Process config in the current dir
-	configFilePath3, err := os.Getwd()
+	// In strict mode, validate all CLI config files before decoding the merged config,
+	// since viper silently ignores the unknown (e.g. misspelled) keys
+	strict, err := isCliConfigStrict(v)
 	if err != nil {
 		return cliConfig, err
 	}
-	configFile3 := path.Join(configFilePath3, CliConfigFileName)
-	found, err = processConfigFile(cliConfig, configFile3, v)
-	if err != nil {
-		return cliConfig, err
-	}
-	if found {
-		configFound = true
-	}
 
-	// Process config from the path in ENV var `ATMOS_CLI_CONFIG_PATH`
-	configFilePath4 := os.Getenv("ATMOS_CLI_CONFIG_PATH")
-	if len(configFilePath4) > 0 {
-		u.LogTrace(cliConfig, fmt.Sprintf("Found ENV var ATMOS_CLI_CONFIG_PATH=%s", configFilePath4))
-		configFile4 := path.Join(configFilePath4, CliConfigFileName)
-		found, err = processConfigFile(cliConfig, configFile4, v)
+	if strict {
+		cliConfigErrors, err := ValidateCliConfigFiles(configFiles)
 		if err != nil {
 			return cliConfig, err
 		}
-		if found {
-			configFound = true
-		}
-	}
-
-	// Process config from the path specified in the Terraform provider (which calls into the atmos code)
-	if configAndStacksInfo.AtmosCliConfigPath != "" {
-		configFilePath5 := configAndStacksInfo.AtmosCliConfigPath
-		if len(configFilePath5) > 0 {
-			configFile5 := path.Join(configFilePath5, CliConfigFileName)
-			found, err = processConfigFile(cliConfig, configFile5, v)
-			if err != nil {
-				return cliConfig, err
-			}
-			if found {
-				configFound = true
-			}
+		if len(cliConfigErrors) > 0 {
+			return cliConfig, newCliConfigValidationError(cliConfigErrors)
 		}
 	}
 
-	if !configFound {
-		return cliConfig, NotFound
-	}
-
 	// https://gist.github.com/chazcheadle/45bf85b793dea2b71bd05ebaa3c28644
 	// https://sagikazarmark.hu/blog/decoding-custom-formats-with-viper/
 	err = v.Unmarshal(&cliConfig)
 	if err != nil {
+		// Report the invalid values with the CLI config files and the positions in the files
+		if cliConfigErrors, validationErr := ValidateCliConfigFiles(configFiles); validationErr == nil && len(cliConfigErrors) > 0 {
+			return cliConfig, newCliConfigValidationError(cliConfigErrors)
+		}
 		return cliConfig, err
 	}
 
+	cliConfig.CliConfigFiles = configFiles
+
 	// Process ENV vars
 	err = processEnvVars(&cliConfig)
 	if err != nil {
@@ -241,6 +192,89 @@ func InitCliConfig(configAndStacksInfo schema.ConfigAndStacksInfo, processStacks
 	return cliConfig, nil
 }
 
+// FindCliConfigFiles returns the CLI config files that exist in the following locations (from lower to higher priority):
+// system dir (`/usr/local/etc/atmos` on Linux, `%LOCALAPPDATA%/atmos` on Windows), home dir (~/.atmos), current directory,
+// the path in the ENV var `ATMOS_CLI_CONFIG_PATH`, the path specified in the Terraform provider, and the file specified in the `--config` flag.
+// The file specified in the `--config` flag must exist
+func FindCliConfigFiles(configAndStacksInfo schema.ConfigAndStacksInfo) ([]string, error) {
+	var configFiles []string
+
+	// Process config in system folder
+	configFilePath1 := ""
+
+	// https://pureinfotech.com/list-environment-variables-windows-10/
+	// https://docs.microsoft.com/en-us/windows/deployment/usmt/usmt-recognized-environment-variables
+	// https://softwareengineering.stackexchange.com/questions/299869/where-is-the-appropriate-place-to-put-application-configuration-files-for-each-p
+	// https://stackoverflow.com/questions/37946282/why-does-appdata-in-windows-7-seemingly-points-to-wrong-folder
+	if runtime.GOOS == "windows" {
+		appDataDir := os.Getenv(WindowsAppDataEnvVar)
+		if len(appDataDir) > 0 {
+			configFilePath1 = appDataDir
+		}
+	} else {
+		configFilePath1 = SystemDirConfigFilePath
+	}
+
+	if len(configFilePath1) > 0 {
+		configFiles = append(configFiles, path.Join(configFilePath1, CliConfigFileName))
+	}
+
+	// Process config in user's HOME dir
+	configFilePath2, err := homedir.Dir()
+	if err != nil {
+		return nil, err
+	}
+	configFiles = append(configFiles, path.Join(configFilePath2, ".atmos", CliConfigFileName))
+
+	// Process config in the current dir
+	configFilePath3, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	configFiles = append(configFiles, path.Join(configFilePath3, CliConfigFileName))
+
+	// Process config from the path in ENV var `ATMOS_CLI_CONFIG_PATH`
+	configFilePath4 := os.Getenv("ATMOS_CLI_CONFIG_PATH")
+	if len(configFilePath4) > 0 {
+		u.LogTrace(schema.CliConfiguration{}, fmt.Sprintf("Found ENV var ATMOS_CLI_CONFIG_PATH=%s", configFilePath4))
+		configFiles = append(configFiles, path.Join(configFilePath4, CliConfigFileName))
+	}
+
+	// Process config from the path specified in the Terraform provider (which calls into the atmos code)
+	if configAndStacksInfo.AtmosCliConfigPath != "" {
+		configFiles = append(configFiles, path.Join(configAndStacksInfo.AtmosCliConfigPath, CliConfigFileName))
+	}
+
+	var result []string
+	for _, configFile := range u.UniqueStrings(configFiles) {
+		if u.FileExists(configFile) {
+			result = append(result, configFile)
+		}
+	}
+
+	// Process config from the file specified in the `--config` command-line flag
+	if configAndStacksInfo.CliConfigFile != "" {
+		if !u.FileExists(configAndStacksInfo.CliConfigFile) {
+			return nil, fmt.Errorf("the CLI config file '%s' specified in the '%s' flag does not exist",
+				configAndStacksInfo.CliConfigFile, CliConfigFileFlag)
+		}
+		if !u.SliceContainsString(result, configAndStacksInfo.CliConfigFile) {
+			result = append(result, configAndStacksInfo.CliConfigFile)
+		}
+	}
+
+	return result, nil
+}
+
+// isCliConfigStrict checks if the strict mode is enabled in the merged CLI config files or in the ENV var `ATMOS_VALIDATE_CONFIG_STRICT`
+func isCliConfigStrict(v *viper.Viper) (bool, error) {
+	validateConfigStrict := os.Getenv("ATMOS_VALIDATE_CONFIG_STRICT")
+	if len(validateConfigStrict) > 0 {
+		return strconv.ParseBool(validateConfigStrict)
+	}
+	return v.GetBool("validate.config.strict"), nil
+}
+
 // https://github.com/NCAR/go-figure
 // https://github.com/spf13/viper/issues/181
 // https://medium.com/@bnprashanth256/reading-configuration-files-and-environment-variables-in-go-golang-c2607f912b63
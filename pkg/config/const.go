@@ -14,6 +14,7 @@ const (
 	TerraformDirFlag            = "--terraform-dir"
 	HelmfileDirFlag             = "--helmfile-dir"
 	CliConfigDirFlag            = "--config-dir"
+	CliConfigFileFlag           = "--config"
 	StackDirFlag                = "--stacks-dir"
 	BasePathFlag                = "--base-path"
 	WorkflowDirFlag             = "--workflows-dir"
@@ -27,11 +28,12 @@ const (
 	AutoGenerateBackendFileFlag = "--auto-generate-backend-file"
 	InitRunReconfigure          = "--init-run-reconfigure"
 
-	FromPlanFlag       = "--from-plan"
-	PlanFileFlag       = "--planfile"
-	DryRunFlag         = "--dry-run"
-	SkipInitFlag       = "--skip-init"
-	RedirectStdErrFlag = "--redirect-stderr"
+	FromPlanFlag          = "--from-plan"
+	PlanFileFlag          = "--planfile"
+	DryRunFlag            = "--dry-run"
+	SkipInitFlag          = "--skip-init"
+	NoValidationCacheFlag = "--no-validation-cache"
+	RedirectStdErrFlag    = "--redirect-stderr"
 
 	HelpFlag1 = "-h"
 	HelpFlag2 = "--help"
@@ -42,3 +44,7 @@ const (
 	ImportSectionName    = "import"
 	OverridesSectionName = "overrides"
 )
+
+// StackConfigFileExtensions are the supported stack manifest file extensions,
+// in the order of precedence when searching for the stack manifests and imports specified without an extension
+var StackConfigFileExtensions = []string{".yaml", ".yml", ".json", ".hcl"}
@@ -25,20 +25,12 @@ func FindAllStackConfigsInPathsForStack(
 	var stackIsDir = strings.IndexAny(stack, "/") > 0
 
 	for _, p := range includeStackPaths {
-		pathWithExt := p
-
-		ext := filepath.Ext(p)
-		if ext == "" {
-			ext = DefaultStackConfigFileExtension
-			pathWithExt = p + ext
-		}
-
 		// Find all matches in the glob
-		matches, err := u.GetGlobMatches(pathWithExt)
+		matches, err := GetStackConfigFileGlobMatches(p)
 		if err != nil || len(matches) == 0 {
 			// Retry (b/c we are using `doublestar` library, and it sometimes has issues reading many files in a Docker container)
 			// TODO: review `doublestar` library
-			matches, err = u.GetGlobMatches(pathWithExt)
+			matches, err = GetStackConfigFileGlobMatches(p)
 			if err != nil {
 				y, _ := u.ConvertToYAML(cliConfig)
 				return nil, nil, false, fmt.Errorf("%v\n\n\nCLI config:\n\n%v", err, y)
@@ -50,7 +42,8 @@ func FindAllStackConfigsInPathsForStack(
 			matchedFileRelativePath := u.TrimBasePathFromPath(cliConfig.StacksBaseAbsolutePath+"/", matchedFileAbsolutePath)
 
 			// Check if the provided stack matches a file in the config folders (excluding the files from `excludeStackPaths`)
-			stackMatch := strings.HasSuffix(matchedFileAbsolutePath, stack+DefaultStackConfigFileExtension)
+			matchedFileAbsolutePathWithoutExt := TrimStackConfigFileExtension(matchedFileAbsolutePath)
+			stackMatch := matchedFileAbsolutePathWithoutExt != matchedFileAbsolutePath && strings.HasSuffix(matchedFileAbsolutePathWithoutExt, stack)
 
 			if stackMatch {
 				allExcluded := true
@@ -92,6 +85,59 @@ func FindAllStackConfigsInPathsForStack(
 	return absolutePaths, relativePaths, false, nil
 }
 
+// GetStackConfigFileGlobMatches returns all stack manifests matching the glob pattern.
+// If the pattern does not have a file extension, the stack manifests with all the supported extensions are searched
+// in the order of precedence defined in `StackConfigFileExtensions`, and if several files differ only in the extension,
+// the file with the highest precedence extension is returned
+func GetStackConfigFileGlobMatches(pattern string) ([]string, error) {
+	if filepath.Ext(pattern) != "" {
+		return u.GetGlobMatches(pattern)
+	}
+
+	var result []string
+	var firstErr error
+	found := map[string]bool{}
+
+	for _, ext := range StackConfigFileExtensions {
+		matches, err := u.GetGlobMatches(pattern + ext)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+
+		for _, match := range matches {
+			matchWithoutExt := strings.TrimSuffix(match, ext)
+			if !found[matchWithoutExt] {
+				found[matchWithoutExt] = true
+				result = append(result, match)
+			}
+		}
+	}
+
+	if len(result) == 0 {
+		return nil, firstErr
+	}
+
+	return result, nil
+}
+
+// IsStackConfigFile checks if the file has one of the supported stack manifest extensions
+func IsStackConfigFile(filePath string) bool {
+	return u.SliceContainsString(StackConfigFileExtensions, filepath.Ext(filePath))
+}
+
+// TrimStackConfigFileExtension removes the supported stack manifest extension (if present) from the file path
+func TrimStackConfigFileExtension(filePath string) string {
+	for _, ext := range StackConfigFileExtensions {
+		if strings.HasSuffix(filePath, ext) {
+			return strings.TrimSuffix(filePath, ext)
+		}
+	}
+	return filePath
+}
+
 // FindAllStackConfigsInPaths finds all stack manifests in the paths specified by globs
 func FindAllStackConfigsInPaths(
 	cliConfig schema.CliConfiguration,
@@ -103,20 +149,12 @@ func FindAllStackConfigsInPaths(
 	var relativePaths []string
 
 	for _, p := range includeStackPaths {
-		pathWithExt := p
-
-		ext := filepath.Ext(p)
-		if ext == "" {
-			ext = DefaultStackConfigFileExtension
-			pathWithExt = p + ext
-		}
-
 		// Find all matches in the glob
-		matches, err := u.GetGlobMatches(pathWithExt)
+		matches, err := GetStackConfigFileGlobMatches(p)
 		if err != nil || len(matches) == 0 {
 			// Retry (b/c we are using `doublestar` library, and it sometimes has issues reading many files in a Docker container)
 			// TODO: review `doublestar` library
-			matches, err = u.GetGlobMatches(pathWithExt)
+			matches, err = GetStackConfigFileGlobMatches(p)
 			if err != nil {
 				y, _ := u.ConvertToYAML(cliConfig)
 				return nil, nil, fmt.Errorf("%v\n\n\nCLI config:\n\n%v", err, y)
@@ -290,6 +328,42 @@ func processEnvVars(cliConfig *schema.CliConfiguration) error {
 		cliConfig.Schemas.Atmos.Manifest = atmosManifestJsonSchemaPath
 	}
 
+	validateStacksStrict := os.Getenv("ATMOS_VALIDATE_STACKS_STRICT")
+	if len(validateStacksStrict) > 0 {
+		u.LogTrace(*cliConfig, fmt.Sprintf("Found ENV var ATMOS_VALIDATE_STACKS_STRICT=%s", validateStacksStrict))
+		validateStacksStrictBool, err := strconv.ParseBool(validateStacksStrict)
+		if err != nil {
+			return err
+		}
+		cliConfig.Validate.Stacks.Strict = validateStacksStrictBool
+	}
+
+	validateConfigStrict := os.Getenv("ATMOS_VALIDATE_CONFIG_STRICT")
+	if len(validateConfigStrict) > 0 {
+		u.LogTrace(*cliConfig, fmt.Sprintf("Found ENV var ATMOS_VALIDATE_CONFIG_STRICT=%s", validateConfigStrict))
+		validateConfigStrictBool, err := strconv.ParseBool(validateConfigStrict)
+		if err != nil {
+			return err
+		}
+		cliConfig.Validate.Config.Strict = validateConfigStrictBool
+	}
+
+	validateCacheDisabled := os.Getenv("ATMOS_VALIDATE_CACHE_DISABLED")
+	if len(validateCacheDisabled) > 0 {
+		u.LogTrace(*cliConfig, fmt.Sprintf("Found ENV var ATMOS_VALIDATE_CACHE_DISABLED=%s", validateCacheDisabled))
+		validateCacheDisabledBool, err := strconv.ParseBool(validateCacheDisabled)
+		if err != nil {
+			return err
+		}
+		cliConfig.Validate.Cache.Disabled = validateCacheDisabledBool
+	}
+
+	validateCachePath := os.Getenv("ATMOS_VALIDATE_CACHE_PATH")
+	if len(validateCachePath) > 0 {
+		u.LogTrace(*cliConfig, fmt.Sprintf("Found ENV var ATMOS_VALIDATE_CACHE_PATH=%s", validateCachePath))
+		cliConfig.Validate.Cache.Path = validateCachePath
+	}
+
 	logsFile := os.Getenv("ATMOS_LOGS_FILE")
 	if len(logsFile) > 0 {
 		u.LogTrace(*cliConfig, fmt.Sprintf("Found ENV var ATMOS_LOGS_FILE=%s", logsFile))
@@ -0,0 +1,148 @@
+package convert
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// HCLToMapOfInterfaces takes an HCL string as input and returns a map[any]any
+// with the same types of the values as returned by YAMLToMapOfInterfaces.
+// Attributes are converted to map items, and blocks are converted to nested maps, e.g. the following HCL
+//
+//	vars = {
+//	  stage = "prod"
+//	}
+//
+//	components "terraform" "vpc" {
+//	  vars = {
+//	    name = "vpc"
+//	  }
+//	}
+//
+// is converted to `{vars: {stage: prod}, components: {terraform: {vpc: {vars: {name: vpc}}}}}`.
+// The blocks with the same type and labels are deep-merged
+func HCLToMapOfInterfaces(fileName string, input string) (map[any]any, error) {
+	file, diags := hclsyntax.ParseConfig([]byte(input), fileName, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	body, ok := file.Body.(*hclsyntax.Body)
+	if !ok {
+		return nil, fmt.Errorf("invalid HCL file '%s'", fileName)
+	}
+
+	return hclBodyToMapOfInterfaces(body)
+}
+
+// hclBodyToMapOfInterfaces recursively converts the attributes and blocks of the HCL body to a map
+func hclBodyToMapOfInterfaces(body *hclsyntax.Body) (map[any]any, error) {
+	res := map[any]any{}
+
+	for name, attr := range body.Attributes {
+		v, diags := attr.Expr.Value(nil)
+		if diags.HasErrors() {
+			return nil, diags
+		}
+
+		i, err := ctyValueToInterface(v)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", attr.SrcRange.String(), err)
+		}
+
+		res[name] = i
+	}
+
+	for _, block := range body.Blocks {
+		blockMap, err := hclBodyToMapOfInterfaces(block.Body)
+		if err != nil {
+			return nil, err
+		}
+
+		// Each label of the block adds a level of nesting
+		var value any = blockMap
+		for i := len(block.Labels) - 1; i >= 0; i-- {
+			value = map[any]any{block.Labels[i]: value}
+		}
+
+		if existing, ok := res[block.Type].(map[any]any); ok {
+			res[block.Type] = deepMergeMapsOfInterfaces(existing, value.(map[any]any))
+		} else if _, ok := res[block.Type]; ok {
+			return nil, fmt.Errorf("%s: the block '%s' conflicts with the attribute with the same name", block.DefRange().String(), block.Type)
+		} else {
+			res[block.Type] = value
+		}
+	}
+
+	return res, nil
+}
+
+// deepMergeMapsOfInterfaces deep-merges the source map into the destination map
+func deepMergeMapsOfInterfaces(dst map[any]any, src map[any]any) map[any]any {
+	for k, v := range src {
+		if srcMap, ok := v.(map[any]any); ok {
+			if dstMap, ok := dst[k].(map[any]any); ok {
+				dst[k] = deepMergeMapsOfInterfaces(dstMap, srcMap)
+				continue
+			}
+		}
+		dst[k] = v
+	}
+	return dst
+}
+
+// ctyValueToInterface recursively converts the cty value to the types used by the YAML decoder
+func ctyValueToInterface(v cty.Value) (any, error) {
+	if v.IsNull() {
+		return nil, nil
+	}
+
+	if !v.IsKnown() {
+		return nil, fmt.Errorf("unknown value")
+	}
+
+	t := v.Type()
+
+	switch {
+	case t == cty.String:
+		return v.AsString(), nil
+	case t == cty.Bool:
+		return v.True(), nil
+	case t == cty.Number:
+		bf := v.AsBigFloat()
+		if bf.IsInt() {
+			if i, accuracy := bf.Int64(); accuracy == 0 {
+				return int(i), nil
+			}
+		}
+		f, _ := bf.Float64()
+		return f, nil
+	case t.IsObjectType() || t.IsMapType():
+		res := map[any]any{}
+		for it := v.ElementIterator(); it.Next(); {
+			k, ev := it.Element()
+			i, err := ctyValueToInterface(ev)
+			if err != nil {
+				return nil, err
+			}
+			res[k.AsString()] = i
+		}
+		return res, nil
+	case t.IsTupleType() || t.IsListType() || t.IsSetType():
+		res := []any{}
+		for it := v.ElementIterator(); it.Next(); {
+			_, ev := it.Element()
+			i, err := ctyValueToInterface(ev)
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, i)
+		}
+		return res, nil
+	default:
+		return nil, fmt.Errorf("unsupported value type '%s'", t.FriendlyName())
+	}
+}
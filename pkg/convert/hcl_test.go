@@ -0,0 +1,47 @@
+package convert
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHCLToMapOfInterfaces(t *testing.T) {
+	input := `
+import = ["catalog/vpc"]
+
+vars = {
+  stage = "prod"
+}
+
+components "terraform" "vpc" {
+  vars = {
+    name  = "vpc"
+    count = 3
+    zones = ["a", "b"]
+  }
+}
+
+components "terraform" "vpc" {
+  settings = {
+    enabled = true
+  }
+}
+`
+	result, err := HCLToMapOfInterfaces("prod.hcl", input)
+	assert.Nil(t, err)
+
+	assert.Equal(t, []any{"catalog/vpc"}, result["import"])
+	assert.Equal(t, "prod", result["vars"].(map[any]any)["stage"])
+
+	vpc := result["components"].(map[any]any)["terraform"].(map[any]any)["vpc"].(map[any]any)
+	assert.Equal(t, "vpc", vpc["vars"].(map[any]any)["name"])
+	assert.Equal(t, 3, vpc["vars"].(map[any]any)["count"])
+	assert.Equal(t, []any{"a", "b"}, vpc["vars"].(map[any]any)["zones"])
+	assert.Equal(t, true, vpc["settings"].(map[any]any)["enabled"])
+}
+
+func TestHCLToMapOfInterfacesRedPath(t *testing.T) {
+	_, err := HCLToMapOfInterfaces("prod.hcl", "vars = {")
+	assert.NotNil(t, err)
+}
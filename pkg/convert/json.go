@@ -1,6 +1,9 @@
 package convert
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"strings"
+)
 
 // JSONToMapOfInterfaces takes a JSON string as input and returns a map[string]any
 func JSONToMapOfInterfaces(input string) (map[string]any, error) {
@@ -32,3 +35,63 @@ func JSONSliceOfInterfaceToSliceOfMaps(input []any) ([]map[any]any, error) {
 	}
 	return outputMap, nil
 }
+
+// JSONToYAMLMapOfInterfaces takes a JSON string as input and returns a map[any]any
+// with the same types of the values as returned by YAMLToMapOfInterfaces (integer numbers are converted to `int`)
+func JSONToYAMLMapOfInterfaces(input string) (map[any]any, error) {
+	var data map[string]any
+
+	decoder := json.NewDecoder(strings.NewReader(input))
+	decoder.UseNumber()
+
+	if err := decoder.Decode(&data); err != nil {
+		return nil, err
+	}
+
+	res, err := jsonValueToYAMLValue(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if res == nil {
+		return nil, nil
+	}
+
+	return res.(map[any]any), nil
+}
+
+// jsonValueToYAMLValue recursively converts the decoded JSON value to the types used by the YAML decoder
+func jsonValueToYAMLValue(v any) (any, error) {
+	switch t := v.(type) {
+	case map[string]any:
+		if t == nil {
+			return nil, nil
+		}
+		res := make(map[any]any, len(t))
+		for k, v2 := range t {
+			v3, err := jsonValueToYAMLValue(v2)
+			if err != nil {
+				return nil, err
+			}
+			res[k] = v3
+		}
+		return res, nil
+	case []any:
+		res := make([]any, len(t))
+		for i, v2 := range t {
+			v3, err := jsonValueToYAMLValue(v2)
+			if err != nil {
+				return nil, err
+			}
+			res[i] = v3
+		}
+		return res, nil
+	case json.Number:
+		if i, err := t.Int64(); err == nil {
+			return int(i), nil
+		}
+		return t.Float64()
+	default:
+		return t, nil
+	}
+}
@@ -18,3 +18,17 @@ func TestJSONToMapOfInterfacesRedPath(t *testing.T) {
 	_, err := JSONToMapOfInterfaces(input)
 	assert.NotNil(t, err)
 }
+
+func TestJSONToYAMLMapOfInterfaces(t *testing.T) {
+	input := `{"vars": {"name": "vpc", "count": 3, "ratio": 0.5, "zones": ["a", "b"], "enabled": true, "tags": null}}`
+	result, err := JSONToYAMLMapOfInterfaces(input)
+	assert.Nil(t, err)
+
+	vars := result["vars"].(map[any]any)
+	assert.Equal(t, "vpc", vars["name"])
+	assert.Equal(t, 3, vars["count"])
+	assert.Equal(t, 0.5, vars["ratio"])
+	assert.Equal(t, []any{"a", "b"}, vars["zones"])
+	assert.Equal(t, true, vars["enabled"])
+	assert.Nil(t, vars["tags"])
+}
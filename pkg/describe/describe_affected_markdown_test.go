@@ -0,0 +1,93 @@
+package describe
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	e "github.com/cloudposse/atmos/internal/exec"
+	"github.com/cloudposse/atmos/pkg/schema"
+)
+
+func TestDescribeAffectedMarkdown(t *testing.T) {
+	affected := []schema.Affected{
+		{Component: "top-level-component1", ComponentType: "terraform", ComponentPath: "components/terraform/top-level-component1", Stack: "tenant1-ue2-dev", Affected: "stack.vars"},
+		{Component: "infra/vpc", ComponentType: "terraform", ComponentPath: "components/terraform/infra/vpc", Stack: "tenant1-ue2-prod", SpaceliftStack: "tenant1-ue2-prod-infra-vpc", Affected: "component"},
+		{Component: "echo-server", ComponentType: "helmfile", ComponentPath: "components/helmfile/echo-server", Stack: "tenant1-ue2-dev", Affected: "component"},
+	}
+
+	planSummaries := map[string]*schema.TerraformPlanSummary{
+		"terraform/tenant1-ue2-dev/top-level-component1": {
+			Add:    1,
+			Change: 2,
+			Resources: []schema.TerraformPlanResourceChange{
+				{Address: "aws_s3_bucket.default", Action: "create"},
+			},
+		},
+	}
+
+	planErrors := map[string]string{
+		"terraform/tenant1-ue2-prod/infra/vpc": "the planfile 'tenant1-ue2-prod-infra-vpc.planfile' does not exist",
+	}
+
+	data := e.BuildAffectedMarkdown(affected, true, planSummaries, planErrors)
+	assert.Equal(t, 3, data.Total)
+	assert.Equal(t, 2, len(data.Stacks))
+	assert.Equal(t, "tenant1-ue2-dev", data.Stacks[0].Stack)
+	// The components in each stack are sorted by name
+	assert.Equal(t, "echo-server", data.Stacks[0].Components[0].Component)
+	assert.Equal(t, "top-level-component1", data.Stacks[0].Components[1].Component)
+
+	markdown, err := e.RenderAffectedMarkdown(data, "")
+	assert.Nil(t, err)
+
+	assert.Contains(t, markdown, "3 affected component(s) in 2 stack(s).")
+	assert.Contains(t, markdown, "### `tenant1-ue2-dev`")
+	assert.Contains(t, markdown, "| `top-level-component1` | terraform | `stack.vars` | 1 | 2 | 0 |")
+	assert.Contains(t, markdown, "| `echo-server` | helmfile | `component` | - | - | - |")
+	assert.Contains(t, markdown, "<summary><code>infra/vpc</code> in <code>tenant1-ue2-prod</code></summary>")
+	assert.Contains(t, markdown, "- Spacelift stack: `tenant1-ue2-prod-infra-vpc`")
+	assert.Contains(t, markdown, ":warning: the planfile 'tenant1-ue2-prod-infra-vpc.planfile' does not exist")
+	assert.Contains(t, markdown, "| `aws_s3_bucket.default` | create |")
+}
+
+func TestDescribeAffectedMarkdownNoAffected(t *testing.T) {
+	markdown, err := e.RenderAffectedMarkdown(e.BuildAffectedMarkdown(nil, false, nil, nil), "")
+	assert.Nil(t, err)
+	assert.Contains(t, markdown, "No affected components.")
+}
+
+func TestDescribeAffectedMarkdownTemplate(t *testing.T) {
+	affected := []schema.Affected{
+		{Component: "top-level-component1", ComponentType: "terraform", Stack: "tenant1-ue2-dev", Affected: "stack.vars"},
+		{Component: "infra/vpc", ComponentType: "terraform", Stack: "tenant1-ue2-dev", Affected: "component"},
+	}
+
+	tmpl := `{{ range .Stacks }}{{ .Stack }}:{{ range .Components }} {{ .Component | upper }}{{ end }}{{ end }}`
+
+	markdown, err := e.RenderAffectedMarkdown(e.BuildAffectedMarkdown(affected, false, nil, nil), tmpl)
+	assert.Nil(t, err)
+	assert.Equal(t, "tenant1-ue2-dev: INFRA/VPC TOP-LEVEL-COMPONENT1", markdown)
+}
+
+func TestTerraformPlanSummaryFromJson(t *testing.T) {
+	planJson := `{
+  "format_version": "1.2",
+  "resource_changes": [
+    {"address": "aws_s3_bucket.new", "change": {"actions": ["create"]}},
+    {"address": "aws_s3_bucket.updated", "change": {"actions": ["update"]}},
+    {"address": "aws_s3_bucket.replaced", "change": {"actions": ["delete", "create"]}},
+    {"address": "aws_s3_bucket.deleted", "change": {"actions": ["delete"]}},
+    {"address": "aws_s3_bucket.unchanged", "change": {"actions": ["no-op"]}},
+    {"address": "data.aws_caller_identity.current", "change": {"actions": ["read"]}}
+  ]
+}`
+
+	summary, err := e.TerraformPlanSummaryFromJson([]byte(planJson))
+	assert.Nil(t, err)
+	assert.Equal(t, 2, summary.Add)
+	assert.Equal(t, 1, summary.Change)
+	assert.Equal(t, 2, summary.Destroy)
+	assert.Equal(t, 4, len(summary.Resources))
+	assert.Equal(t, "replace", summary.Resources[2].Action)
+}
@@ -0,0 +1,121 @@
+package describe
+
+import (
+	"encoding/json"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	e "github.com/cloudposse/atmos/internal/exec"
+	cfg "github.com/cloudposse/atmos/pkg/config"
+	"github.com/cloudposse/atmos/pkg/schema"
+)
+
+func TestDescribeAffectedMatrix(t *testing.T) {
+	cliConfig, err := cfg.InitCliConfig(schema.ConfigAndStacksInfo{}, true)
+	assert.Nil(t, err)
+
+	// `top-level-component1` depends on `test/test-component-override` and `test/test-component` in the `tenant1-ue2-dev` stack
+	affected := []schema.Affected{
+		{Component: "top-level-component1", ComponentType: "terraform", Stack: "tenant1-ue2-dev", StackSlug: "tenant1-ue2-dev-top-level-component1", Tenant: "tenant1", Environment: "ue2", Stage: "dev", Affected: "stack.vars"},
+		{Component: "test/test-component-override", ComponentType: "terraform", Stack: "tenant1-ue2-dev", StackSlug: "tenant1-ue2-dev-test-test-component-override", Tenant: "tenant1", Environment: "ue2", Stage: "dev", Affected: "component"},
+		{Component: "test/test-component", ComponentType: "terraform", Stack: "tenant1-ue2-dev", StackSlug: "tenant1-ue2-dev-test-test-component", Tenant: "tenant1", Environment: "ue2", Stage: "dev", Affected: "component"},
+		{Component: "infra/vpc", ComponentType: "terraform", Stack: "tenant1-ue2-prod", StackSlug: "tenant1-ue2-prod-infra-vpc", Tenant: "tenant1", Environment: "ue2", Stage: "prod", Affected: "component"},
+	}
+
+	items, err := e.BuildAffectedMatrix(cliConfig, affected)
+	assert.Nil(t, err)
+	assert.Equal(t, 4, len(items))
+
+	levels := map[string]int{}
+	for _, item := range items {
+		levels[item.StackSlug] = item.DependencyLevel
+	}
+
+	assert.Equal(t, 1, levels["tenant1-ue2-dev-top-level-component1"])
+	assert.Equal(t, 0, levels["tenant1-ue2-dev-test-test-component-override"])
+	assert.Equal(t, 0, levels["tenant1-ue2-dev-test-test-component"])
+	assert.Equal(t, 0, levels["tenant1-ue2-prod-infra-vpc"])
+
+	// The items are ordered by the dependency level
+	assert.Equal(t, "top-level-component1", items[3].Component)
+
+	grouped := e.GroupAffectedMatrixByDependencyLevel(items)
+	assert.Equal(t, e.AffectedMatrixVersion, grouped.Version)
+	assert.Equal(t, 2, len(grouped.Levels))
+	assert.Equal(t, 3, len(grouped.Levels[0].Include))
+	assert.Equal(t, 1, len(grouped.Levels[1].Include))
+}
+
+func TestDescribeAffectedMatrixOutput(t *testing.T) {
+	cliConfig, err := cfg.InitCliConfig(schema.ConfigAndStacksInfo{}, true)
+	assert.Nil(t, err)
+
+	affected := []schema.Affected{
+		{Component: "infra/vpc", ComponentType: "terraform", Stack: "tenant1-ue2-prod", StackSlug: "tenant1-ue2-prod-infra-vpc", Tenant: "tenant1", Environment: "ue2", Stage: "prod", Affected: "component"},
+	}
+
+	dir := t.TempDir()
+	file := path.Join(dir, "matrix.json")
+	githubOutputFile := path.Join(dir, "github-output")
+	t.Setenv("GITHUB_OUTPUT", githubOutputFile)
+
+	err = e.PrintOrWriteAffectedMatrix(cliConfig, affected, "", file, true)
+	assert.Nil(t, err)
+
+	// The flat output contains the version of the matrix schema
+	content, err := os.ReadFile(file)
+	assert.Nil(t, err)
+
+	var matrix schema.AffectedMatrix
+	err = json.Unmarshal(content, &matrix)
+	assert.Nil(t, err)
+	assert.Equal(t, e.AffectedMatrixVersion, matrix.Version)
+	assert.Equal(t, 1, len(matrix.Include))
+	assert.Equal(t, "tenant1-ue2-prod-infra-vpc", matrix.Include[0].StackSlug)
+
+	// The `matrix` output is a valid GitHub Actions matrix (without the version), and the version is in the `matrix_version` output
+	outputs, err := os.ReadFile(githubOutputFile)
+	assert.Nil(t, err)
+
+	lines := strings.Split(strings.TrimSpace(string(outputs)), "\n")
+	assert.Equal(t, 3, len(lines))
+	assert.Equal(t, "has_affected=true", lines[0])
+	assert.True(t, strings.HasPrefix(lines[1], `matrix={"include":[{"component":"infra/vpc",`))
+	assert.Equal(t, "matrix_version=1", lines[2])
+}
+
+func TestWriteGithubOutputs(t *testing.T) {
+	cliConfig, err := cfg.InitCliConfig(schema.ConfigAndStacksInfo{}, true)
+	assert.Nil(t, err)
+
+	githubOutputFile := path.Join(t.TempDir(), "github-output")
+
+	// The outputs are appended to the existing outputs written by the previous steps
+	err = os.WriteFile(githubOutputFile, []byte("previous=value\n"), 0644)
+	assert.Nil(t, err)
+
+	err = e.WriteGithubOutputs(cliConfig, githubOutputFile, map[string]any{
+		"matrix_levels": 2,
+		"has_affected":  false,
+		"matrix_level_0": schema.AffectedMatrix{
+			Include: []schema.AffectedMatrixItem{{Component: "vpc", Stack: "tenant1-ue2-dev"}},
+		},
+	})
+	assert.Nil(t, err)
+
+	content, err := os.ReadFile(githubOutputFile)
+	assert.Nil(t, err)
+
+	// The outputs are sorted by name, and each value is written as compact JSON on a single line
+	lines := strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+	assert.Equal(t, 4, len(lines))
+	assert.Equal(t, "previous=value", lines[0])
+	assert.Equal(t, "has_affected=false", lines[1])
+	assert.True(t, strings.HasPrefix(lines[2], `matrix_level_0={"include":[{"component":"vpc",`))
+	assert.NotContains(t, lines[2], "version")
+	assert.Equal(t, "matrix_levels=2", lines[3])
+}
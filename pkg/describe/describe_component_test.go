@@ -2,6 +2,7 @@ package describe
 
 import (
 	e "github.com/cloudposse/atmos/internal/exec"
+	"github.com/cloudposse/atmos/pkg/schema"
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/yaml.v2"
 	"testing"
@@ -64,3 +65,91 @@ func TestDescribeComponent5(t *testing.T) {
 	assert.Nil(t, err)
 	t.Log(string(componentSectionYaml))
 }
+
+func TestDescribeComponentWithProvenance(t *testing.T) {
+	component := "test/test-component-override-3"
+	stack := "tenant1-ue2-dev"
+
+	componentSection, err := e.ExecuteDescribeComponentWithProvenance(component, stack)
+	assert.Nil(t, err)
+
+	provenance := componentSection["provenance"].(schema.ConfigProvenance)
+	assert.Contains(t, provenance, "vars")
+	assert.Contains(t, provenance, "settings")
+	assert.Contains(t, provenance, "env")
+	assert.Contains(t, provenance, "backend")
+	assert.Contains(t, provenance, "metadata")
+
+	for _, section := range provenance {
+		for _, item := range section {
+			assert.NotEmpty(t, item.StackFile)
+		}
+	}
+
+	// The values defined in the component's manifest point to the file and line where they are set
+	envVar1 := provenance["env"]["TEST_ENV_VAR1"]
+	assert.Equal(t, "val1-override-3", envVar1.FinalValue)
+	assert.Equal(t, "catalog/terraform/test-component-override-3", envVar1.StackFile)
+	assert.Equal(t, "components.terraform.env", envVar1.StackFileSection)
+	assert.Equal(t, 25, envVar1.Line)
+
+	envVar3 := provenance["env"]["TEST_ENV_VAR3"]
+	assert.Equal(t, "catalog/terraform/test-component-override-3", envVar3.StackFile)
+	assert.Equal(t, 27, envVar3.Line)
+
+	// The values inherited from the imported manifests point to the imported file
+	backendBucket := provenance["backend"]["bucket"]
+	assert.Equal(t, "cp-ue2-root-tfstate", backendBucket.FinalValue)
+	assert.Equal(t, "catalog/terraform/spacelift-and-backend-override-1", backendBucket.StackFile)
+	assert.Equal(t, "terraform.backend.s3", backendBucket.StackFileSection)
+	assert.Equal(t, 15, backendBucket.Line)
+
+	componentSectionYaml, err := yaml.Marshal(provenance)
+	assert.Nil(t, err)
+	t.Log(string(componentSectionYaml))
+}
+
+func TestIsComponentEnabled(t *testing.T) {
+	vars := map[any]any{
+		"stage":   "prod",
+		"region":  "us-east-2",
+		"enabled": true,
+		"count":   3,
+	}
+
+	// No `metadata.enabled` and `metadata.enabled_when`
+	assert.True(t, e.IsComponentEnabled(map[any]any{}, vars))
+	assert.True(t, e.IsComponentEnabled(nil, nil))
+
+	// `metadata.enabled`
+	assert.True(t, e.IsComponentEnabled(map[any]any{"enabled": true}, vars))
+	assert.False(t, e.IsComponentEnabled(map[any]any{"enabled": false}, vars))
+	assert.False(t, e.IsComponentEnabled(map[any]any{"enabled": false}, nil))
+
+	// `metadata.enabled: false` takes precedence over the satisfied `metadata.enabled_when` conditions
+	assert.False(t, e.IsComponentEnabled(map[any]any{
+		"enabled":      false,
+		"enabled_when": map[any]any{"stage": "prod"},
+	}, vars))
+
+	// Scalar values in `metadata.enabled_when`
+	assert.True(t, e.IsComponentEnabled(map[any]any{"enabled_when": map[any]any{"stage": "prod"}}, vars))
+	assert.False(t, e.IsComponentEnabled(map[any]any{"enabled_when": map[any]any{"stage": "dev"}}, vars))
+
+	// Lists of allowed values in `metadata.enabled_when`
+	assert.True(t, e.IsComponentEnabled(map[any]any{"enabled_when": map[any]any{"region": []any{"us-west-2", "us-east-2"}}}, vars))
+	assert.False(t, e.IsComponentEnabled(map[any]any{"enabled_when": map[any]any{"region": []any{"us-west-2", "eu-west-1"}}}, vars))
+
+	// All conditions must be satisfied
+	assert.True(t, e.IsComponentEnabled(map[any]any{"enabled_when": map[any]any{"stage": "prod", "region": []any{"us-east-2"}}}, vars))
+	assert.False(t, e.IsComponentEnabled(map[any]any{"enabled_when": map[any]any{"stage": "prod", "region": []any{"us-west-2"}}}, vars))
+
+	// The values are compared as strings, so `true` matches "true", and 3 matches "3"
+	assert.True(t, e.IsComponentEnabled(map[any]any{"enabled_when": map[any]any{"enabled": "true"}}, vars))
+	assert.True(t, e.IsComponentEnabled(map[any]any{"enabled_when": map[any]any{"count": []any{"2", "3"}}}, vars))
+	assert.False(t, e.IsComponentEnabled(map[any]any{"enabled_when": map[any]any{"enabled": "false"}}, vars))
+
+	// The component is disabled if the variable from `metadata.enabled_when` is not set in the component's `vars`
+	assert.False(t, e.IsComponentEnabled(map[any]any{"enabled_when": map[any]any{"tenant": "plat"}}, vars))
+	assert.False(t, e.IsComponentEnabled(map[any]any{"enabled_when": map[any]any{"stage": "prod"}}, nil))
+}
@@ -0,0 +1,50 @@
+package generate
+
+import (
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	e "github.com/cloudposse/atmos/internal/exec"
+	cfg "github.com/cloudposse/atmos/pkg/config"
+	"github.com/cloudposse/atmos/pkg/schema"
+)
+
+func TestTerraformGenerateSchema(t *testing.T) {
+	cliConfig, err := cfg.InitCliConfig(schema.ConfigAndStacksInfo{}, true)
+	assert.Nil(t, err)
+
+	component := "infra/vpc"
+	componentPath := path.Join(cliConfig.TerraformDirAbsolutePath, component)
+
+	jsonSchema, err := e.ExecuteTerraformGenerateSchema(component, componentPath)
+	assert.Nil(t, err)
+
+	vars := jsonSchema["properties"].(map[string]any)["vars"].(map[string]any)
+	assert.Equal(t, false, vars["additionalProperties"])
+	assert.Equal(t, []string{"region", "subnet_type_tag_key"}, vars["required"])
+
+	properties := vars["properties"].(map[string]any)
+
+	// `type = number`, nullable
+	maxSubnetCount := properties["max_subnet_count"].(map[string]any)
+	assert.Equal(t, []any{"number", "null"}, maxSubnetCount["type"])
+	assert.Equal(t, float64(0), maxSubnetCount["default"])
+
+	// `length(var.ipv4_cidrs) < 2`
+	ipv4Cidrs := properties["ipv4_cidrs"].(map[string]any)
+	assert.Equal(t, int64(1), ipv4Cidrs["maxItems"])
+	assert.Equal(t, []string{"private", "public"}, ipv4Cidrs["items"].(map[string]any)["required"])
+
+	// `var.label_key_case == null ? true : contains(["lower", "title", "upper"], var.label_key_case)`
+	labelKeyCase := properties["label_key_case"].(map[string]any)
+	assert.Equal(t, []any{"lower", "title", "upper", nil}, labelKeyCase["enum"])
+
+	// The conditions that can't be converted to JSON Schema are added to the comments
+	idLengthLimit := properties["id_length_limit"].(map[string]any)
+	assert.Contains(t, idLengthLimit["$comment"], "The id_length_limit must be >= 6")
+
+	_, err = e.ExecuteTerraformGenerateSchema("infra/does-not-exist", path.Join(cliConfig.TerraformDirAbsolutePath, "infra/does-not-exist"))
+	assert.NotNil(t, err)
+}
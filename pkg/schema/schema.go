@@ -10,6 +10,7 @@ type CliConfiguration struct {
 	Commands                      []Command    `yaml:"commands" json:"commands" mapstructure:"commands"`
 	Integrations                  Integrations `yaml:"integrations" json:"integrations" mapstructure:"integrations"`
 	Schemas                       Schemas      `yaml:"schemas" json:"schemas" mapstructure:"schemas"`
+	Validate                      Validate     `yaml:"validate" json:"validate" mapstructure:"validate"`
 	Initialized                   bool         `yaml:"initialized" json:"initialized" mapstructure:"initialized"`
 	StacksBaseAbsolutePath        string       `yaml:"stacksBaseAbsolutePath" json:"stacksBaseAbsolutePath"`
 	IncludeStackAbsolutePaths     []string     `yaml:"includeStackAbsolutePaths" json:"includeStackAbsolutePaths"`
@@ -19,6 +20,8 @@ type CliConfiguration struct {
 	StackConfigFilesRelativePaths []string     `yaml:"stackConfigFilesRelativePaths" json:"stackConfigFilesRelativePaths"`
 	StackConfigFilesAbsolutePaths []string     `yaml:"stackConfigFilesAbsolutePaths" json:"stackConfigFilesAbsolutePaths"`
 	StackType                     string       `yaml:"stackType" json:"StackType"`
+	// CliConfigFiles are the CLI config files (layers) that were found and merged, from lowest to highest priority
+	CliConfigFiles []string `yaml:"cliConfigFiles" json:"cliConfigFiles"`
 }
 
 type Terraform struct {
@@ -92,12 +95,14 @@ type ArgsAndFlagsInfo struct {
 	PlanFile                string
 	DryRun                  bool
 	SkipInit                bool
+	NoValidationCache       bool
 	NeedHelp                bool
 	JsonSchemaDir           string
 	OpaDir                  string
 	CueDir                  string
 	AtmosManifestJsonSchema string
 	RedirectStdErr          string
+	CliConfigFile           string
 }
 
 type ConfigAndStacksInfo struct {
@@ -140,10 +145,13 @@ type ConfigAndStacksInfo struct {
 	PlanFile                      string
 	DryRun                        bool
 	SkipInit                      bool
+	NoValidationCache             bool
 	ComponentInheritanceChain     []string
 	ComponentImportsSection       []string
 	NeedHelp                      bool
 	ComponentIsAbstract           bool
+	ComponentIsEnabled            bool
+	Provenance                    bool
 	ComponentMetadataSection      map[any]any
 	TerraformWorkspace            string
 	JsonSchemaDir                 string
@@ -153,6 +161,7 @@ type ConfigAndStacksInfo struct {
 	AtmosCliConfigPath            string
 	AtmosBasePath                 string
 	RedirectStdErr                string
+	CliConfigFile                 string
 }
 
 // Workflows
@@ -274,7 +283,24 @@ type CommandComponentConfig struct {
 // Integrations
 
 type Integrations struct {
-	Atlantis Atlantis `yaml:"atlantis" json:"atlantis" mapstructure:"atlantis"`
+	Atlantis  Atlantis   `yaml:"atlantis" json:"atlantis" mapstructure:"atlantis"`
+	GitLab    CIPipeline `yaml:"gitlab" json:"gitlab" mapstructure:"gitlab"`
+	Buildkite CIPipeline `yaml:"buildkite" json:"buildkite" mapstructure:"buildkite"`
+}
+
+// CI pipeline integrations (GitLab CI, Buildkite)
+
+type CIPipeline struct {
+	Path              string                    `yaml:"path" json:"path" mapstructure:"path"`
+	PipelineTemplates map[string]map[string]any `yaml:"pipeline_templates" json:"pipeline_templates" mapstructure:"pipeline_templates"`
+	JobTemplates      map[string]CIJobTemplate  `yaml:"job_templates" json:"job_templates" mapstructure:"job_templates"`
+}
+
+type CIJobTemplate struct {
+	Name        string         `yaml:"name" json:"name" mapstructure:"name"`
+	ManualApply *bool          `yaml:"manual_apply,omitempty" json:"manual_apply,omitempty" mapstructure:"manual_apply"`
+	Plan        map[string]any `yaml:"plan" json:"plan" mapstructure:"plan"`
+	Apply       map[string]any `yaml:"apply" json:"apply" mapstructure:"apply"`
 }
 
 // Atlantis integration
@@ -287,23 +313,68 @@ type Atlantis struct {
 }
 
 type AtlantisRepoConfig struct {
-	Version                   int      `yaml:"version" json:"version" mapstructure:"version"`
-	Automerge                 bool     `yaml:"automerge" json:"automerge" mapstructure:"automerge"`
-	DeleteSourceBranchOnMerge bool     `yaml:"delete_source_branch_on_merge" json:"delete_source_branch_on_merge" mapstructure:"delete_source_branch_on_merge"`
-	ParallelPlan              bool     `yaml:"parallel_plan" json:"parallel_plan" mapstructure:"parallel_plan"`
-	ParallelApply             bool     `yaml:"parallel_apply" json:"parallel_apply" mapstructure:"parallel_apply"`
-	AllowedRegexpPrefixes     []string `yaml:"allowed_regexp_prefixes" json:"allowed_regexp_prefixes" mapstructure:"allowed_regexp_prefixes"`
+	Version                   int                         `yaml:"version" json:"version" mapstructure:"version"`
+	Automerge                 bool                        `yaml:"automerge" json:"automerge" mapstructure:"automerge"`
+	Autodiscover              *AtlantisAutodiscoverConfig `yaml:"autodiscover,omitempty" json:"autodiscover,omitempty" mapstructure:"autodiscover"`
+	DeleteSourceBranchOnMerge bool                        `yaml:"delete_source_branch_on_merge" json:"delete_source_branch_on_merge" mapstructure:"delete_source_branch_on_merge"`
+	ParallelPlan              bool                        `yaml:"parallel_plan" json:"parallel_plan" mapstructure:"parallel_plan"`
+	ParallelApply             bool                        `yaml:"parallel_apply" json:"parallel_apply" mapstructure:"parallel_apply"`
+	AbortOnExecutionOrderFail bool                        `yaml:"abort_on_execution_order_fail,omitempty" json:"abort_on_execution_order_fail,omitempty" mapstructure:"abort_on_execution_order_fail"`
+	AllowedRegexpPrefixes     []string                    `yaml:"allowed_regexp_prefixes" json:"allowed_regexp_prefixes" mapstructure:"allowed_regexp_prefixes"`
+	AllowedOverrides          []string                    `yaml:"allowed_overrides,omitempty" json:"allowed_overrides,omitempty" mapstructure:"allowed_overrides"`
+	AllowedWorkflows          []string                    `yaml:"allowed_workflows,omitempty" json:"allowed_workflows,omitempty" mapstructure:"allowed_workflows"`
+	AllowCustomWorkflows      bool                        `yaml:"allow_custom_workflows,omitempty" json:"allow_custom_workflows,omitempty" mapstructure:"allow_custom_workflows"`
+	Policies                  *AtlantisPoliciesConfig     `yaml:"policies,omitempty" json:"policies,omitempty" mapstructure:"policies"`
+	// Extra contains the fields that are not modeled by Atmos. They are added to the Atlantis config as is
+	Extra map[string]any `yaml:",inline" json:"extra,omitempty" mapstructure:",remain"`
+}
+
+type AtlantisAutodiscoverConfig struct {
+	Mode        string   `yaml:"mode,omitempty" json:"mode,omitempty" mapstructure:"mode"`
+	IgnorePaths []string `yaml:"ignore_paths,omitempty" json:"ignore_paths,omitempty" mapstructure:"ignore_paths"`
+}
+
+type AtlantisPoliciesConfig struct {
+	ConftestVersion string                    `yaml:"conftest_version,omitempty" json:"conftest_version,omitempty" mapstructure:"conftest_version"`
+	Owners          *AtlantisPolicyOwners     `yaml:"owners,omitempty" json:"owners,omitempty" mapstructure:"owners"`
+	PolicySets      []AtlantisPolicySetConfig `yaml:"policy_sets,omitempty" json:"policy_sets,omitempty" mapstructure:"policy_sets"`
+}
+
+type AtlantisPolicyOwners struct {
+	Users []string `yaml:"users,omitempty" json:"users,omitempty" mapstructure:"users"`
+	Teams []string `yaml:"teams,omitempty" json:"teams,omitempty" mapstructure:"teams"`
+}
+
+type AtlantisPolicySetConfig struct {
+	Name              string                `yaml:"name" json:"name" mapstructure:"name"`
+	Path              string                `yaml:"path" json:"path" mapstructure:"path"`
+	Source            string                `yaml:"source" json:"source" mapstructure:"source"`
+	Owners            *AtlantisPolicyOwners `yaml:"owners,omitempty" json:"owners,omitempty" mapstructure:"owners"`
+	PreventSelfReview bool                  `yaml:"prevent_self_review,omitempty" json:"prevent_self_review,omitempty" mapstructure:"prevent_self_review"`
 }
 
 type AtlantisProjectConfig struct {
 	Name                      string                        `yaml:"name" json:"name" mapstructure:"name"`
+	Branch                    string                        `yaml:"branch,omitempty" json:"branch,omitempty" mapstructure:"branch"`
 	Workspace                 string                        `yaml:"workspace" json:"workspace" mapstructure:"workspace"`
 	Workflow                  string                        `yaml:"workflow,omitempty" json:"workflow,omitempty" mapstructure:"workflow"`
 	Dir                       string                        `yaml:"dir" json:"dir" mapstructure:"dir"`
 	TerraformVersion          string                        `yaml:"terraform_version" json:"terraform_version" mapstructure:"terraform_version"`
+	TerraformDistribution     string                        `yaml:"terraform_distribution,omitempty" json:"terraform_distribution,omitempty" mapstructure:"terraform_distribution"`
 	DeleteSourceBranchOnMerge bool                          `yaml:"delete_source_branch_on_merge" json:"delete_source_branch_on_merge" mapstructure:"delete_source_branch_on_merge"`
 	Autoplan                  AtlantisProjectAutoplanConfig `yaml:"autoplan" json:"autoplan" mapstructure:"autoplan"`
+	PlanRequirements          []string                      `yaml:"plan_requirements,omitempty" json:"plan_requirements,omitempty" mapstructure:"plan_requirements"`
 	ApplyRequirements         []string                      `yaml:"apply_requirements" json:"apply_requirements" mapstructure:"apply_requirements"`
+	ImportRequirements        []string                      `yaml:"import_requirements,omitempty" json:"import_requirements,omitempty" mapstructure:"import_requirements"`
+	RepoLocking               *bool                         `yaml:"repo_locking,omitempty" json:"repo_locking,omitempty" mapstructure:"repo_locking"`
+	RepoLocks                 *AtlantisProjectRepoLocks     `yaml:"repo_locks,omitempty" json:"repo_locks,omitempty" mapstructure:"repo_locks"`
+	CustomPolicyCheck         bool                          `yaml:"custom_policy_check,omitempty" json:"custom_policy_check,omitempty" mapstructure:"custom_policy_check"`
+	PolicyCheck               *bool                         `yaml:"policy_check,omitempty" json:"policy_check,omitempty" mapstructure:"policy_check"`
+	SilencePRComments         []string                      `yaml:"silence_pr_comments,omitempty" json:"silence_pr_comments,omitempty" mapstructure:"silence_pr_comments"`
+	DependsOn                 []string                      `yaml:"depends_on,omitempty" json:"depends_on,omitempty" mapstructure:"depends_on"`
+	ExecutionOrderGroup       int                           `yaml:"execution_order_group,omitempty" json:"execution_order_group,omitempty" mapstructure:"execution_order_group"`
+	// Extra contains the fields that are not modeled by Atmos. They are added to the Atlantis project as is
+	Extra map[string]any `yaml:",inline" json:"extra,omitempty" mapstructure:",remain"`
 }
 
 type AtlantisProjectAutoplanConfig struct {
@@ -311,15 +382,26 @@ type AtlantisProjectAutoplanConfig struct {
 	WhenModified []string `yaml:"when_modified" json:"when_modified" mapstructure:"when_modified"`
 }
 
+type AtlantisProjectRepoLocks struct {
+	Mode string `yaml:"mode" json:"mode" mapstructure:"mode"`
+}
+
 type AtlantisConfigOutput struct {
-	Version                   int                     `yaml:"version" json:"version" mapstructure:"version"`
-	Automerge                 bool                    `yaml:"automerge" json:"automerge" mapstructure:"automerge"`
-	DeleteSourceBranchOnMerge bool                    `yaml:"delete_source_branch_on_merge" json:"delete_source_branch_on_merge" mapstructure:"delete_source_branch_on_merge"`
-	ParallelPlan              bool                    `yaml:"parallel_plan" json:"parallel_plan" mapstructure:"parallel_plan"`
-	ParallelApply             bool                    `yaml:"parallel_apply" json:"parallel_apply" mapstructure:"parallel_apply"`
-	AllowedRegexpPrefixes     []string                `yaml:"allowed_regexp_prefixes" json:"allowed_regexp_prefixes" mapstructure:"allowed_regexp_prefixes"`
-	Projects                  []AtlantisProjectConfig `yaml:"projects" json:"projects" mapstructure:"projects"`
-	Workflows                 map[string]any          `yaml:"workflows,omitempty" json:"workflows,omitempty" mapstructure:"workflows"`
+	Version                   int                         `yaml:"version" json:"version" mapstructure:"version"`
+	Automerge                 bool                        `yaml:"automerge" json:"automerge" mapstructure:"automerge"`
+	Autodiscover              *AtlantisAutodiscoverConfig `yaml:"autodiscover,omitempty" json:"autodiscover,omitempty" mapstructure:"autodiscover"`
+	DeleteSourceBranchOnMerge bool                        `yaml:"delete_source_branch_on_merge" json:"delete_source_branch_on_merge" mapstructure:"delete_source_branch_on_merge"`
+	ParallelPlan              bool                        `yaml:"parallel_plan" json:"parallel_plan" mapstructure:"parallel_plan"`
+	ParallelApply             bool                        `yaml:"parallel_apply" json:"parallel_apply" mapstructure:"parallel_apply"`
+	AbortOnExecutionOrderFail bool                        `yaml:"abort_on_execution_order_fail,omitempty" json:"abort_on_execution_order_fail,omitempty" mapstructure:"abort_on_execution_order_fail"`
+	AllowedRegexpPrefixes     []string                    `yaml:"allowed_regexp_prefixes" json:"allowed_regexp_prefixes" mapstructure:"allowed_regexp_prefixes"`
+	AllowedOverrides          []string                    `yaml:"allowed_overrides,omitempty" json:"allowed_overrides,omitempty" mapstructure:"allowed_overrides"`
+	AllowedWorkflows          []string                    `yaml:"allowed_workflows,omitempty" json:"allowed_workflows,omitempty" mapstructure:"allowed_workflows"`
+	AllowCustomWorkflows      bool                        `yaml:"allow_custom_workflows,omitempty" json:"allow_custom_workflows,omitempty" mapstructure:"allow_custom_workflows"`
+	Policies                  *AtlantisPoliciesConfig     `yaml:"policies,omitempty" json:"policies,omitempty" mapstructure:"policies"`
+	Projects                  []AtlantisProjectConfig     `yaml:"projects" json:"projects" mapstructure:"projects"`
+	Workflows                 map[string]any              `yaml:"workflows,omitempty" json:"workflows,omitempty" mapstructure:"workflows"`
+	Extra                     map[string]any              `yaml:",inline" json:"extra,omitempty" mapstructure:",remain"`
 }
 
 // Validation schemas
@@ -347,6 +429,26 @@ type Schemas struct {
 	Atmos      AtmosSchema `yaml:"atmos" json:"atmos" mapstructure:"atmos"`
 }
 
+type ValidateStacks struct {
+	Strict bool `yaml:"strict" json:"strict" mapstructure:"strict"`
+}
+
+type ValidateConfig struct {
+	Strict bool `yaml:"strict" json:"strict" mapstructure:"strict"`
+}
+
+type ValidateCache struct {
+	Disabled bool   `yaml:"disabled" json:"disabled" mapstructure:"disabled"`
+	Path     string `yaml:"path" json:"path" mapstructure:"path"`
+}
+
+type Validate struct {
+	Stacks   ValidateStacks         `yaml:"stacks" json:"stacks" mapstructure:"stacks"`
+	Config   ValidateConfig         `yaml:"config" json:"config" mapstructure:"config"`
+	Cache    ValidateCache          `yaml:"cache" json:"cache" mapstructure:"cache"`
+	Policies map[string]StackPolicy `yaml:"policies,omitempty" json:"policies,omitempty" mapstructure:"policies"`
+}
+
 type ValidationItem struct {
 	SchemaType  string   `yaml:"schema_type" json:"schema_type" mapstructure:"schema_type"`
 	SchemaPath  string   `yaml:"schema_path" json:"schema_path" mapstructure:"schema_path"`
@@ -354,10 +456,86 @@ type ValidationItem struct {
 	Description string   `yaml:"description" json:"description" mapstructure:"description"`
 	Disabled    bool     `yaml:"disabled" json:"disabled" mapstructure:"disabled"`
 	Timeout     int      `yaml:"timeout" json:"timeout" mapstructure:"timeout"`
+	// FailOnSeverity is the minimum severity of the OPA policy errors that fail the validation
+	FailOnSeverity string `yaml:"fail_on_severity,omitempty" json:"fail_on_severity,omitempty" mapstructure:"fail_on_severity"`
 }
 
 type Validation map[string]ValidationItem
 
+// ValidationTest is a fixture-based test of the validation policies.
+// The test validates the component in the stack (or the inline component config) and compares the errors and warnings with the expected ones
+type ValidationTest struct {
+	Name             string         `yaml:"name" json:"name" mapstructure:"name"`
+	Component        string         `yaml:"component,omitempty" json:"component,omitempty" mapstructure:"component"`
+	Stack            string         `yaml:"stack,omitempty" json:"stack,omitempty" mapstructure:"stack"`
+	Config           map[string]any `yaml:"config,omitempty" json:"config,omitempty" mapstructure:"config"`
+	SchemaType       string         `yaml:"schema_type,omitempty" json:"schema_type,omitempty" mapstructure:"schema_type"`
+	SchemaPath       string         `yaml:"schema_path,omitempty" json:"schema_path,omitempty" mapstructure:"schema_path"`
+	ModulePaths      []string       `yaml:"module_paths,omitempty" json:"module_paths,omitempty" mapstructure:"module_paths"`
+	Timeout          int            `yaml:"timeout,omitempty" json:"timeout,omitempty" mapstructure:"timeout"`
+	FailOnSeverity   string         `yaml:"fail_on_severity,omitempty" json:"fail_on_severity,omitempty" mapstructure:"fail_on_severity"`
+	ExpectedErrors   []string       `yaml:"expected_errors,omitempty" json:"expected_errors,omitempty" mapstructure:"expected_errors"`
+	ExpectedWarnings []string       `yaml:"expected_warnings,omitempty" json:"expected_warnings,omitempty" mapstructure:"expected_warnings"`
+}
+
+// ValidationTestFile is a file with the fixture-based tests of the validation policies
+type ValidationTestFile struct {
+	Tests []ValidationTest `yaml:"tests" json:"tests" mapstructure:"tests"`
+}
+
+// ValidationTestResult is the result of an OPA test (`test_` rule in a `*_test.rego` file) or a fixture-based test (in a `*_test.yaml` file)
+type ValidationTestResult struct {
+	Type     string   `yaml:"type" json:"type" mapstructure:"type"`
+	File     string   `yaml:"file" json:"file" mapstructure:"file"`
+	Name     string   `yaml:"name" json:"name" mapstructure:"name"`
+	Passed   bool     `yaml:"passed" json:"passed" mapstructure:"passed"`
+	Skipped  bool     `yaml:"skipped,omitempty" json:"skipped,omitempty" mapstructure:"skipped"`
+	Messages []string `yaml:"messages,omitempty" json:"messages,omitempty" mapstructure:"messages"`
+}
+
+// StackPolicy is a policy that validates the stacks (the `describe stacks` output) instead of a single component.
+// The `repo` scope (default) validates all stacks at once, the `stack` scope validates each stack separately
+type StackPolicy struct {
+	ValidationItem `yaml:",inline" mapstructure:",squash"`
+	Scope          string `yaml:"scope,omitempty" json:"scope,omitempty" mapstructure:"scope"`
+}
+
+// PolicyViolation is an error or a warning reported by an OPA policy.
+// The stack manifest file and line are found from the `sources` of the component using the path of the violation
+type PolicyViolation struct {
+	Message   string `yaml:"msg" json:"msg" mapstructure:"msg"`
+	Path      string `yaml:"path,omitempty" json:"path,omitempty" mapstructure:"path"`
+	Severity  string `yaml:"severity,omitempty" json:"severity,omitempty" mapstructure:"severity"`
+	RuleId    string `yaml:"rule_id,omitempty" json:"rule_id,omitempty" mapstructure:"rule_id"`
+	StackFile string `yaml:"stack_file,omitempty" json:"stack_file,omitempty" mapstructure:"stack_file"`
+	Line      int    `yaml:"line,omitempty" json:"line,omitempty" mapstructure:"line"`
+}
+
+// PolicyResult is the result of evaluating an OPA policy: the errors (`data.atmos.errors`), the warnings (`data.atmos.warnings`),
+// and the minimum severity of the errors that fail the validation (`data.atmos.fail_on_severity`)
+type PolicyResult struct {
+	Errors         []PolicyViolation `yaml:"errors,omitempty" json:"errors,omitempty" mapstructure:"errors"`
+	Warnings       []PolicyViolation `yaml:"warnings,omitempty" json:"warnings,omitempty" mapstructure:"warnings"`
+	FailOnSeverity string            `yaml:"fail_on_severity,omitempty" json:"fail_on_severity,omitempty" mapstructure:"fail_on_severity"`
+}
+
+// ComponentValidationResult is the result of validating a component in a stack using one of the policies from the `settings.validation` section
+type ComponentValidationResult struct {
+	Policy        string `yaml:"policy" json:"policy" mapstructure:"policy"`
+	Description   string `yaml:"description,omitempty" json:"description,omitempty" mapstructure:"description"`
+	SchemaType    string `yaml:"schema_type" json:"schema_type" mapstructure:"schema_type"`
+	SchemaPath    string `yaml:"schema_path" json:"schema_path" mapstructure:"schema_path"`
+	Component     string `yaml:"component" json:"component" mapstructure:"component"`
+	ComponentType string `yaml:"component_type" json:"component_type" mapstructure:"component_type"`
+	Stack         string `yaml:"stack" json:"stack" mapstructure:"stack"`
+	StackFile     string `yaml:"stack_file" json:"stack_file" mapstructure:"stack_file"`
+	Passed        bool   `yaml:"passed" json:"passed" mapstructure:"passed"`
+	Message       string `yaml:"message,omitempty" json:"message,omitempty" mapstructure:"message"`
+	// Violations and Warnings are the structured errors and warnings reported by OPA policies
+	Violations []PolicyViolation `yaml:"violations,omitempty" json:"violations,omitempty" mapstructure:"violations"`
+	Warnings   []PolicyViolation `yaml:"warnings,omitempty" json:"warnings,omitempty" mapstructure:"warnings"`
+}
+
 // Affected Atmos components and stacks given two Git commits
 
 type Affected struct {
@@ -377,6 +555,79 @@ type Affected struct {
 	Folder          string `yaml:"folder,omitempty" json:"folder,omitempty" mapstructure:"folder"`
 }
 
+// AffectedMatrix is a GitHub Actions matrix of the affected components (the output of `atmos describe affected --format matrix`).
+// The version is set only in the top-level output, and is omitted in the matrices used by GitHub Actions
+type AffectedMatrix struct {
+	Version int                  `yaml:"version,omitempty" json:"version,omitempty" mapstructure:"version"`
+	Include []AffectedMatrixItem `yaml:"include" json:"include" mapstructure:"include"`
+}
+
+// AffectedMatrixItem is an affected component in a stack in the GitHub Actions matrix.
+// All the fields are always present in the output, so the workflows can rely on them
+type AffectedMatrixItem struct {
+	Component       string `yaml:"component" json:"component" mapstructure:"component"`
+	ComponentType   string `yaml:"component_type" json:"component_type" mapstructure:"component_type"`
+	ComponentPath   string `yaml:"component_path" json:"component_path" mapstructure:"component_path"`
+	Namespace       string `yaml:"namespace" json:"namespace" mapstructure:"namespace"`
+	Tenant          string `yaml:"tenant" json:"tenant" mapstructure:"tenant"`
+	Environment     string `yaml:"environment" json:"environment" mapstructure:"environment"`
+	Stage           string `yaml:"stage" json:"stage" mapstructure:"stage"`
+	Stack           string `yaml:"stack" json:"stack" mapstructure:"stack"`
+	StackSlug       string `yaml:"stack_slug" json:"stack_slug" mapstructure:"stack_slug"`
+	SpaceliftStack  string `yaml:"spacelift_stack" json:"spacelift_stack" mapstructure:"spacelift_stack"`
+	AtlantisProject string `yaml:"atlantis_project" json:"atlantis_project" mapstructure:"atlantis_project"`
+	Affected        string `yaml:"affected" json:"affected" mapstructure:"affected"`
+	DependencyLevel int    `yaml:"dependency_level" json:"dependency_level" mapstructure:"dependency_level"`
+}
+
+// AffectedMatrixLevels is the GitHub Actions matrices of the affected components grouped by the dependency level
+type AffectedMatrixLevels struct {
+	Version int              `yaml:"version" json:"version" mapstructure:"version"`
+	Levels  []AffectedMatrix `yaml:"levels" json:"levels" mapstructure:"levels"`
+}
+
+// AffectedMarkdown is the data for the template of `atmos describe affected --format markdown`
+type AffectedMarkdown struct {
+	Stacks      []AffectedMarkdownStack `yaml:"stacks" json:"stacks" mapstructure:"stacks"`
+	Total       int                     `yaml:"total" json:"total" mapstructure:"total"`
+	PlanSummary bool                    `yaml:"plan_summary" json:"plan_summary" mapstructure:"plan_summary"`
+}
+
+// AffectedMarkdownStack is the affected components in a stack
+type AffectedMarkdownStack struct {
+	Stack      string                      `yaml:"stack" json:"stack" mapstructure:"stack"`
+	Components []AffectedMarkdownComponent `yaml:"components" json:"components" mapstructure:"components"`
+}
+
+// AffectedMarkdownComponent is an affected component in a stack, with the summary of the Terraform plan (if `--plan-summary` is used).
+// `PlanError` is set if the plan summary can't be read (e.g. the planfile does not exist)
+type AffectedMarkdownComponent struct {
+	Component       string                `yaml:"component" json:"component" mapstructure:"component"`
+	ComponentType   string                `yaml:"component_type" json:"component_type" mapstructure:"component_type"`
+	ComponentPath   string                `yaml:"component_path" json:"component_path" mapstructure:"component_path"`
+	Stack           string                `yaml:"stack" json:"stack" mapstructure:"stack"`
+	SpaceliftStack  string                `yaml:"spacelift_stack" json:"spacelift_stack" mapstructure:"spacelift_stack"`
+	AtlantisProject string                `yaml:"atlantis_project" json:"atlantis_project" mapstructure:"atlantis_project"`
+	Affected        string                `yaml:"affected" json:"affected" mapstructure:"affected"`
+	Plan            *TerraformPlanSummary `yaml:"plan,omitempty" json:"plan,omitempty" mapstructure:"plan"`
+	PlanError       string                `yaml:"plan_error,omitempty" json:"plan_error,omitempty" mapstructure:"plan_error"`
+}
+
+// TerraformPlanSummary is the number of resources to add, change and destroy in a Terraform plan, and the changed resources
+type TerraformPlanSummary struct {
+	Add       int                           `yaml:"add" json:"add" mapstructure:"add"`
+	Change    int                           `yaml:"change" json:"change" mapstructure:"change"`
+	Destroy   int                           `yaml:"destroy" json:"destroy" mapstructure:"destroy"`
+	Resources []TerraformPlanResourceChange `yaml:"resources" json:"resources" mapstructure:"resources"`
+}
+
+// TerraformPlanResourceChange is a resource change in a Terraform plan.
+// `Action` is `create`, `update`, `delete` or `replace`
+type TerraformPlanResourceChange struct {
+	Address string `yaml:"address" json:"address" mapstructure:"address"`
+	Action  string `yaml:"action" json:"action" mapstructure:"action"`
+}
+
 type BaseComponentConfig struct {
 	BaseComponentVars                      map[any]any
 	BaseComponentSettings                  map[any]any
@@ -387,6 +638,7 @@ type BaseComponentConfig struct {
 	BaseComponentBackendSection            map[any]any
 	BaseComponentRemoteStateBackendType    string
 	BaseComponentRemoteStateBackendSection map[any]any
+	BaseComponentMetadata                  map[any]any
 	ComponentInheritanceChain              []string
 }
 
@@ -432,6 +684,8 @@ type ConfigSourcesStackDependency struct {
 	StackFileSection string `yaml:"stack_file_section" json:"stack_file_section" mapstructure:"stack_file_section"`
 	DependencyType   string `yaml:"dependency_type" json:"dependency_type" mapstructure:"dependency_type"`
 	VariableValue    any    `yaml:"variable_value" json:"variable_value" mapstructure:"variable_value"`
+	Component        string `yaml:"component,omitempty" json:"component,omitempty" mapstructure:"component"`
+	Line             int    `yaml:"line,omitempty" json:"line,omitempty" mapstructure:"line"`
 }
 
 type ConfigSourcesStackDependencies []ConfigSourcesStackDependency
@@ -444,6 +698,20 @@ type ConfigSourcesItem struct {
 
 type ConfigSources map[string]map[string]ConfigSourcesItem
 
+// ConfigProvenanceItem defines where the final value of a variable or setting was set:
+// the component in the inheritance chain (the component itself or one of its base components),
+// and the stack manifest file and line
+type ConfigProvenanceItem struct {
+	FinalValue       any    `yaml:"final_value" json:"final_value" mapstructure:"final_value"`
+	Component        string `yaml:"component,omitempty" json:"component,omitempty" mapstructure:"component"`
+	StackFile        string `yaml:"stack_file" json:"stack_file" mapstructure:"stack_file"`
+	StackFileSection string `yaml:"stack_file_section" json:"stack_file_section" mapstructure:"stack_file_section"`
+	Line             int    `yaml:"line,omitempty" json:"line,omitempty" mapstructure:"line"`
+	DependencyType   string `yaml:"dependency_type" json:"dependency_type" mapstructure:"dependency_type"`
+}
+
+type ConfigProvenance map[string]map[string]ConfigProvenanceItem
+
 // Atmos vendoring (`vendor.yaml` file)
 
 type AtmosVendorSource struct {
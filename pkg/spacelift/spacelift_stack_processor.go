@@ -1,13 +1,6 @@
 package spacelift
 
 import (
-	"fmt"
-	"sort"
-	"strings"
-
-	"github.com/mitchellh/mapstructure"
-	"github.com/pkg/errors"
-
 	e "github.com/cloudposse/atmos/internal/exec"
 	cfg "github.com/cloudposse/atmos/pkg/config"
 	"github.com/cloudposse/atmos/pkg/schema"
@@ -84,306 +77,5 @@ func TransformStackConfigToSpaceliftStacks(
 	rawStackConfigs map[string]map[string]any,
 ) (map[string]any, error) {
 
-	var err error
-	res := map[string]any{}
-
-	allStackNames, err := e.BuildSpaceliftStackNames(stacks, stackNamePattern)
-	if err != nil {
-		return nil, err
-	}
-
-	for stackName, stackConfig := range stacks {
-		config := stackConfig.(map[any]any)
-		var imports []string
-
-		if processImports {
-			if i, ok := config["imports"]; ok {
-				imports = i.([]string)
-			}
-		}
-
-		if i, ok := config["components"]; ok {
-			componentsSection := i.(map[string]any)
-
-			if terraformComponents, ok := componentsSection["terraform"]; ok {
-				terraformComponentsMap := terraformComponents.(map[string]any)
-
-				for component, v := range terraformComponentsMap {
-					componentMap := v.(map[string]any)
-
-					componentSettings := map[any]any{}
-					if i, ok2 := componentMap["settings"]; ok2 {
-						componentSettings = i.(map[any]any)
-					}
-
-					spaceliftSettings := map[any]any{}
-					spaceliftWorkspaceEnabled := false
-
-					if i, ok2 := componentSettings["spacelift"]; ok2 {
-						spaceliftSettings = i.(map[any]any)
-
-						if i3, ok3 := spaceliftSettings["workspace_enabled"]; ok3 {
-							spaceliftWorkspaceEnabled = i3.(bool)
-						}
-					}
-
-					// If Spacelift workspace is disabled, don't include it, continue to the next component
-					if !spaceliftWorkspaceEnabled {
-						continue
-					}
-
-					spaceliftExplicitLabels := []any{}
-					if i, ok2 := spaceliftSettings["labels"]; ok2 {
-						spaceliftExplicitLabels = i.([]any)
-					}
-
-					spaceliftConfig := map[string]any{}
-					spaceliftConfig["enabled"] = spaceliftWorkspaceEnabled
-
-					componentVars := map[any]any{}
-					if i, ok2 := componentMap["vars"]; ok2 {
-						componentVars = i.(map[any]any)
-					}
-
-					componentEnv := map[any]any{}
-					if i, ok2 := componentMap["env"]; ok2 {
-						componentEnv = i.(map[any]any)
-					}
-
-					componentStacks := []string{}
-					if i, ok2 := componentMap["stacks"]; ok2 {
-						componentStacks = i.([]string)
-					}
-
-					componentInheritance := []string{}
-					if i, ok2 := componentMap["inheritance"]; ok2 {
-						componentInheritance = i.([]string)
-					}
-
-					// Process component metadata and find a base component (if any) and whether the component is real or abstract
-					componentMetadata, baseComponentName, componentIsAbstract := e.ProcessComponentMetadata(component, componentMap)
-
-					if componentIsAbstract {
-						continue
-					}
-
-					context := cfg.GetContextFromVars(componentVars)
-					context.Component = component
-					context.BaseComponent = baseComponentName
-
-					var contextPrefix string
-
-					if stackNamePattern != "" {
-						contextPrefix, err = cfg.GetContextPrefix(stackName, context, stackNamePattern, stackName)
-						if err != nil {
-							u.LogError(err)
-							return nil, err
-						}
-					} else {
-						contextPrefix = strings.Replace(stackName, "/", "-", -1)
-					}
-
-					spaceliftConfig["component"] = component
-					spaceliftConfig["stack"] = contextPrefix
-					spaceliftConfig["imports"] = imports
-					spaceliftConfig["vars"] = componentVars
-					spaceliftConfig["settings"] = componentSettings
-					spaceliftConfig["env"] = componentEnv
-					spaceliftConfig["stacks"] = componentStacks
-					spaceliftConfig["inheritance"] = componentInheritance
-					spaceliftConfig["base_component"] = baseComponentName
-					spaceliftConfig["metadata"] = componentMetadata
-
-					// backend
-					backendTypeName := ""
-					if backendType, backendTypeExist := componentMap["backend_type"]; backendTypeExist {
-						backendTypeName = backendType.(string)
-					}
-					spaceliftConfig["backend_type"] = backendTypeName
-
-					componentBackend := map[any]any{}
-					if i, ok2 := componentMap["backend"]; ok2 {
-						componentBackend = i.(map[any]any)
-					}
-					spaceliftConfig["backend"] = componentBackend
-
-					// Component dependencies
-					configAndStacksInfo := schema.ConfigAndStacksInfo{
-						ComponentFromArg:          component,
-						ComponentType:             "terraform",
-						StackFile:                 stackName,
-						ComponentVarsSection:      componentVars,
-						ComponentEnvSection:       componentEnv,
-						ComponentSettingsSection:  componentSettings,
-						ComponentBackendSection:   componentBackend,
-						ComponentBackendType:      backendTypeName,
-						ComponentInheritanceChain: componentInheritance,
-					}
-
-					sources, err := e.ProcessConfigSources(configAndStacksInfo, rawStackConfigs)
-					if err != nil {
-						return nil, err
-					}
-
-					componentDeps, componentDepsAll, err := e.FindComponentDependencies(stackName, sources)
-					if err != nil {
-						return nil, err
-					}
-
-					spaceliftConfig["deps"] = componentDeps
-					spaceliftConfig["deps_all"] = componentDepsAll
-
-					// Terraform workspace
-					workspace, err := e.BuildTerraformWorkspace(
-						stackName,
-						stackNamePattern,
-						componentMetadata,
-						context,
-					)
-					if err != nil {
-						u.LogError(err)
-						return nil, err
-					}
-					spaceliftConfig["workspace"] = workspace
-
-					// labels
-					labels := []string{}
-					for _, v := range imports {
-						labels = append(labels, fmt.Sprintf("import:"+stackConfigPathTemplate, v))
-					}
-					for _, v := range componentStacks {
-						labels = append(labels, fmt.Sprintf("stack:"+stackConfigPathTemplate, v))
-					}
-					for _, v := range componentDeps {
-						labels = append(labels, fmt.Sprintf("deps:"+stackConfigPathTemplate, v))
-					}
-					for _, v := range spaceliftExplicitLabels {
-						labels = append(labels, v.(string))
-					}
-
-					var terraformComponentNamesInCurrentStack []string
-
-					for v2 := range terraformComponentsMap {
-						terraformComponentNamesInCurrentStack = append(terraformComponentNamesInCurrentStack, strings.Replace(v2, "/", "-", -1))
-					}
-
-					// Legacy/deprecated `settings.spacelift.depends_on`
-					spaceliftDependsOn := []any{}
-					if i, ok2 := spaceliftSettings["depends_on"]; ok2 {
-						spaceliftDependsOn = i.([]any)
-					}
-
-					var spaceliftStackNameDependsOnLabels1 []string
-
-					for _, dep := range spaceliftDependsOn {
-						spaceliftStackNameDependsOn, err := e.BuildDependentStackNameFromDependsOnLegacy(
-							dep.(string),
-							allStackNames,
-							contextPrefix,
-							terraformComponentNamesInCurrentStack,
-							component,
-						)
-						if err != nil {
-							u.LogError(err)
-							return nil, err
-						}
-						spaceliftStackNameDependsOnLabels1 = append(spaceliftStackNameDependsOnLabels1, fmt.Sprintf("depends-on:%s", spaceliftStackNameDependsOn))
-					}
-
-					sort.Strings(spaceliftStackNameDependsOnLabels1)
-					labels = append(labels, spaceliftStackNameDependsOnLabels1...)
-
-					// Recommended `settings.depends_on`
-					var stackComponentSettingsDependsOn schema.Settings
-					err = mapstructure.Decode(componentSettings, &stackComponentSettingsDependsOn)
-					if err != nil {
-						return nil, err
-					}
-
-					var spaceliftStackNameDependsOnLabels2 []string
-
-					for _, stackComponentSettingsDependsOnContext := range stackComponentSettingsDependsOn.DependsOn {
-						if stackComponentSettingsDependsOnContext.Component == "" {
-							continue
-						}
-
-						if stackComponentSettingsDependsOnContext.Namespace == "" {
-							stackComponentSettingsDependsOnContext.Namespace = context.Namespace
-						}
-						if stackComponentSettingsDependsOnContext.Tenant == "" {
-							stackComponentSettingsDependsOnContext.Tenant = context.Tenant
-						}
-						if stackComponentSettingsDependsOnContext.Environment == "" {
-							stackComponentSettingsDependsOnContext.Environment = context.Environment
-						}
-						if stackComponentSettingsDependsOnContext.Stage == "" {
-							stackComponentSettingsDependsOnContext.Stage = context.Stage
-						}
-
-						var contextPrefixDependsOn string
-
-						if stackNamePattern != "" {
-							contextPrefixDependsOn, err = cfg.GetContextPrefix(
-								stackName,
-								stackComponentSettingsDependsOnContext,
-								stackNamePattern,
-								stackName,
-							)
-							if err != nil {
-								return nil, err
-							}
-						} else {
-							contextPrefixDependsOn = strings.Replace(stackName, "/", "-", -1)
-						}
-
-						spaceliftStackNameDependsOn, err := e.BuildDependentStackNameFromDependsOn(
-							component,
-							contextPrefix,
-							stackComponentSettingsDependsOnContext.Component,
-							contextPrefixDependsOn,
-							allStackNames,
-						)
-						if err != nil {
-							u.LogError(err)
-							return nil, err
-						}
-						spaceliftStackNameDependsOnLabels2 = append(spaceliftStackNameDependsOnLabels2, fmt.Sprintf("depends-on:%s", spaceliftStackNameDependsOn))
-					}
-
-					sort.Strings(spaceliftStackNameDependsOnLabels2)
-					labels = append(labels, spaceliftStackNameDependsOnLabels2...)
-
-					// Add `component` and `folder` labels
-					labels = append(labels, fmt.Sprintf("folder:component/%s", component))
-					labels = append(labels, fmt.Sprintf("folder:%s", strings.Replace(contextPrefix, "-", "/", -1)))
-
-					spaceliftConfig["labels"] = u.UniqueStrings(labels)
-
-					// Spacelift stack name
-					spaceliftStackName, spaceliftStackNamePattern := e.BuildSpaceliftStackName(spaceliftSettings, context, contextPrefix)
-
-					// Add Spacelift stack config to the final map
-					spaceliftStackNameKey := strings.Replace(spaceliftStackName, "/", "-", -1)
-
-					if !u.MapKeyExists(res, spaceliftStackNameKey) {
-						res[spaceliftStackNameKey] = spaceliftConfig
-					} else {
-						errorMessage := fmt.Sprintf("\nDuplicate Spacelift stack name '%s' for component '%s' in the stack '%s'."+
-							"\nCheck if the component name is correct and the Spacelift stack name pattern 'stack_name_pattern=%s' is specific enough."+
-							"\nDid you specify the correct context tokens {namespace}, {tenant}, {environment}, {stage}, {component}?",
-							spaceliftStackName,
-							component,
-							stackName,
-							spaceliftStackNamePattern,
-						)
-						er := errors.New(errorMessage)
-						u.LogError(er)
-						return nil, er
-					}
-				}
-			}
-		}
-	}
-
-	return res, nil
+	return e.TransformStackConfigToSpaceliftStacks(stacks, stackConfigPathTemplate, stackNamePattern, processImports, rawStackConfigs)
 }
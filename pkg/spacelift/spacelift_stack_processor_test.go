@@ -5,6 +5,10 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/yaml.v2"
+
+	e "github.com/cloudposse/atmos/internal/exec"
+	cfg "github.com/cloudposse/atmos/pkg/config"
+	"github.com/cloudposse/atmos/pkg/schema"
 )
 
 func TestSpaceliftStackProcessor(t *testing.T) {
@@ -182,3 +186,44 @@ func TestLegacySpaceliftStackProcessor(t *testing.T) {
 	assert.Nil(t, err)
 	t.Log(string(yamlSpaceliftStacks))
 }
+
+func TestSpaceliftGenerateStacksTerraformJson(t *testing.T) {
+	cliConfig, err := cfg.InitCliConfig(schema.ConfigAndStacksInfo{}, true)
+	assert.Nil(t, err)
+
+	spaceliftStacks, err := e.ExecuteSpaceliftGenerateStacks(cliConfig, "stacks/%s.yaml")
+	assert.Nil(t, err)
+
+	terraformJson, err := e.BuildSpaceliftTerraformResources(cliConfig, spaceliftStacks, "infrastructure", "main")
+	assert.Nil(t, err)
+
+	resources := terraformJson["resource"].(map[string]any)
+
+	stacks := resources["spacelift_stack"].(map[string]any)
+	assert.Equal(t, len(spaceliftStacks), len(stacks))
+
+	stack := stacks["tenant1-ue2-dev-top-level-component1"].(map[string]any)
+	assert.Equal(t, "tenant1-ue2-dev-top-level-component1", stack["name"])
+	assert.Equal(t, "infrastructure", stack["repository"])
+	assert.Equal(t, "main", stack["branch"])
+	assert.Equal(t, "components/terraform/top-level-component1", stack["project_root"])
+	assert.Equal(t, "tenant1-ue2-dev", stack["terraform_workspace"])
+
+	dependencies := resources["spacelift_stack_dependency"].(map[string]any)
+	dependency := dependencies["tenant1-ue2-dev-top-level-component1__tenant1-ue2-dev-test-test-component"].(map[string]any)
+	assert.Equal(t, "${spacelift_stack.tenant1-ue2-dev-top-level-component1.id}", dependency["stack_id"])
+	assert.Equal(t, "${spacelift_stack.tenant1-ue2-dev-test-test-component.id}", dependency["depends_on_stack_id"])
+
+	// The repository is required
+	_, err = e.BuildSpaceliftTerraformResources(cliConfig, spaceliftStacks, "", "main")
+	assert.NotNil(t, err)
+
+	// The stack names that are converted to the same Terraform resource name are not allowed
+	_, err = e.BuildSpaceliftTerraformResources(cliConfig, map[string]any{
+		"tenant1-ue2-dev-vpc.1": map[string]any{"component": "vpc"},
+		"tenant1-ue2-dev-vpc_1": map[string]any{"component": "vpc"},
+	}, "infrastructure", "main")
+	assert.NotNil(t, err)
+	assert.Equal(t, "the Spacelift stacks 'tenant1-ue2-dev-vpc.1' and 'tenant1-ue2-dev-vpc_1' have the same Terraform resource name "+
+		"'tenant1-ue2-dev-vpc_1'. Check if the Spacelift stack name pattern is specific enough", err.Error())
+}
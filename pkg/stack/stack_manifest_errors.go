@@ -0,0 +1,357 @@
+package stack
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	yamlv3 "gopkg.in/yaml.v3"
+
+	m "github.com/cloudposse/atmos/pkg/merge"
+	u "github.com/cloudposse/atmos/pkg/utils"
+)
+
+var (
+	// Imports (absolute paths of the imported files) of all stack manifests processed in the current run.
+	// Used to find the import chain that led to the stack manifest with an error. Cleared at the beginning of each run
+	// (see `ResetStackManifestImports`), so it does not grow and does not contain stale imports from the previous runs
+	stackManifestImports     = map[string][]string{}
+	stackManifestImportsLock = &sync.RWMutex{}
+
+	// Matches the invalid section or attribute in the error messages, e.g. "invalid 'components.terraform.vpc.vars' section in the file"
+	invalidSectionErrorRegexp = regexp.MustCompile(`invalid '([^']+)' (?:section|attribute)`)
+
+	// Matches the line in the YAML parser errors, e.g. "yaml: line 12: mapping values are not allowed in this context"
+	yamlErrorLineRegexp = regexp.MustCompile(`line (\d+)`)
+
+	// Matches the line and column in the Go template errors, e.g. "template: catalog/vpc.yaml:17:12: executing ..."
+	templateErrorPositionRegexp = regexp.MustCompile(`^template: [^:]+:(\d+)(?::(\d+))?:`)
+)
+
+// StackManifestError is an error in a stack manifest.
+// It contains the position (line and column) of the invalid section or attribute in the stack manifest,
+// and the chain of imports that led to the stack manifest
+type StackManifestError struct {
+	// File is the path to the stack manifest relative to the stacks base path
+	File   string
+	Line   int
+	Column int
+	// ImportChain is the list of the stack manifests (starting from the top-level stack manifest) that led to the file
+	ImportChain []string
+	Err         error
+}
+
+// Error returns the error message in the `file:line:col: error` format followed by the import chain
+func (e *StackManifestError) Error() string {
+	var sb strings.Builder
+
+	sb.WriteString(e.File)
+	if e.Line > 0 {
+		sb.WriteString(":" + strconv.Itoa(e.Line))
+		if e.Column > 0 {
+			sb.WriteString(":" + strconv.Itoa(e.Column))
+		}
+	}
+	sb.WriteString(": ")
+	sb.WriteString(e.Err.Error())
+
+	if len(e.ImportChain) > 1 {
+		sb.WriteString("\nimport chain: ")
+		sb.WriteString(strings.Join(e.ImportChain, " -> "))
+	}
+
+	return sb.String()
+}
+
+// Unwrap returns the original error
+func (e *StackManifestError) Unwrap() error {
+	return e.Err
+}
+
+// setStackManifestImports saves the imports of the stack manifest
+func setStackManifestImports(filePath string, imports []string) {
+	stackManifestImportsLock.Lock()
+	defer stackManifestImportsLock.Unlock()
+
+	stackManifestImports[filePath] = imports
+}
+
+// ResetStackManifestImports clears the imports of the stack manifests processed in the previous runs.
+// It's called at the beginning of `ProcessYAMLConfigFiles`, and should be called before processing the stack manifests one by one
+// with `ProcessYAMLConfigFile`
+func ResetStackManifestImports() {
+	stackManifestImportsLock.Lock()
+	defer stackManifestImportsLock.Unlock()
+
+	stackManifestImports = map[string][]string{}
+}
+
+// getStackManifestImports returns the imports of the stack manifest
+func getStackManifestImports(filePath string) []string {
+	stackManifestImportsLock.RLock()
+	defer stackManifestImportsLock.RUnlock()
+
+	return stackManifestImports[filePath]
+}
+
+// newStackManifestError returns an error with the position of the key (if found) in the stack manifest.
+// If the key path is empty, the position is taken from the YAML parser and Go template errors (if present)
+func newStackManifestError(basePath string, filePath string, keyPath []string, err error) error {
+	var stackManifestError *StackManifestError
+	if errors.As(err, &stackManifestError) {
+		return err
+	}
+
+	relativeFilePath := u.TrimBasePathFromPath(basePath+"/", filePath)
+
+	result := &StackManifestError{
+		File:        relativeFilePath,
+		ImportChain: []string{relativeFilePath},
+		Err:         err,
+	}
+
+	if len(keyPath) > 0 {
+		if root := parseStackManifestNode(filePath); root != nil {
+			result.Line, result.Column = u.FindYAMLKeyPosition(root, keyPath)
+		}
+	} else {
+		result.Line, result.Column = findErrorPosition(err)
+	}
+
+	return result
+}
+
+// addImportToStackManifestError adds the importing stack manifest to the import chain of the error
+func addImportToStackManifestError(basePath string, filePath string, err error) error {
+	relativeFilePath := u.TrimBasePathFromPath(basePath+"/", filePath)
+
+	var stackManifestError *StackManifestError
+	if !errors.As(err, &stackManifestError) {
+		return &StackManifestError{
+			File:        relativeFilePath,
+			ImportChain: []string{relativeFilePath},
+			Err:         err,
+		}
+	}
+
+	stackManifestError.ImportChain = append([]string{relativeFilePath}, stackManifestError.ImportChain...)
+	return stackManifestError
+}
+
+// locateStackManifestError finds the stack manifest (the stack itself or one of its imports) and the position of the invalid section
+// or attribute from the error message. Since the error is detected in the deep-merged stack config,
+// the stack manifests are searched in the order of their precedence in the deep-merge
+// (the stack manifest itself, then its imports in reverse order)
+func locateStackManifestError(basePath string, stackFilePath string, err error) error {
+	var stackManifestError *StackManifestError
+	if errors.As(err, &stackManifestError) {
+		return err
+	}
+
+	relativeStackFilePath := u.TrimBasePathFromPath(basePath+"/", stackFilePath)
+
+	result := &StackManifestError{
+		File:        relativeStackFilePath,
+		ImportChain: []string{relativeStackFilePath},
+		Err:         err,
+	}
+
+	matches := invalidSectionErrorRegexp.FindStringSubmatch(err.Error())
+	if len(matches) < 2 {
+		return result
+	}
+
+	keyPath := strings.Split(matches[1], ".")
+
+	// The errors from the base components processing contain the key path relative to the component section
+	keyPaths := [][]string{
+		keyPath,
+		append([]string{"components", "terraform"}, keyPath...),
+		append([]string{"components", "helmfile"}, keyPath...),
+	}
+
+	chain, line, column := findStackManifestKeyPosition(stackFilePath, keyPaths, nil, map[string]bool{})
+	if line == 0 {
+		return result
+	}
+
+	result.ImportChain = []string{}
+	for _, p := range chain {
+		result.ImportChain = append(result.ImportChain, u.TrimBasePathFromPath(basePath+"/", p))
+	}
+	result.File = result.ImportChain[len(result.ImportChain)-1]
+	result.Line = line
+	result.Column = column
+
+	return result
+}
+
+// findStackManifestKeyPosition recursively searches for the key paths in the stack manifest and its imports,
+// and returns the import chain to the stack manifest where a key path is found, and the position of the key
+func findStackManifestKeyPosition(filePath string, keyPaths [][]string, chain []string, visited map[string]bool) ([]string, int, int) {
+	if visited[filePath] {
+		return nil, 0, 0
+	}
+	visited[filePath] = true
+
+	chain = append(chain, filePath)
+
+	if root := parseStackManifestNode(filePath); root != nil {
+		for _, keyPath := range keyPaths {
+			if line, column := u.FindYAMLKeyPosition(root, keyPath); line > 0 {
+				return chain, line, column
+			}
+		}
+	}
+
+	imports := getStackManifestImports(filePath)
+	for i := len(imports) - 1; i >= 0; i-- {
+		importChain, line, column := findStackManifestKeyPosition(imports[i], keyPaths, append([]string{}, chain...), visited)
+		if line > 0 {
+			return importChain, line, column
+		}
+	}
+
+	return nil, 0, 0
+}
+
+// parseStackManifestNode reads and parses the stack manifest into a YAML node tree.
+// The stack manifests are parsed only when an error is reported, so it does not affect the performance of the stack processing
+func parseStackManifestNode(filePath string) *yamlv3.Node {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil
+	}
+
+	root, err := u.ParseYAMLNode(string(content))
+	if err != nil {
+		return nil
+	}
+
+	return root
+}
+
+// findErrorPosition returns the line and column from the YAML parser and Go template errors
+func findErrorPosition(err error) (int, int) {
+	message := err.Error()
+
+	if matches := templateErrorPositionRegexp.FindStringSubmatch(message); len(matches) > 1 {
+		line, _ := strconv.Atoi(matches[1])
+		column, _ := strconv.Atoi(matches[2])
+		return line, column
+	}
+
+	if strings.HasPrefix(message, "yaml:") {
+		if matches := yamlErrorLineRegexp.FindStringSubmatch(message); len(matches) > 1 {
+			line, _ := strconv.Atoi(matches[1])
+			return line, 0
+		}
+	}
+
+	return 0, 0
+}
+
+// mergeStackSection deep-merges the maps. If the maps can't be deep-merged, the returned error contains the key path
+// (prefixed with `sectionPath`) of the value that can't be merged, so the position of the value in the stack manifests can be found
+func mergeStackSection(sectionPath []string, stackName string, inputs []map[any]any) (map[any]any, error) {
+	result, err := m.Merge(inputs)
+	if err == nil {
+		return result, nil
+	}
+
+	keyPath := findMergeConflictKeyPath(inputs)
+	if len(keyPath) == 0 {
+		return nil, err
+	}
+
+	keyPath = append(append([]string{}, sectionPath...), keyPath...)
+	return nil, fmt.Errorf("invalid '%s' attribute in the file '%s': %w", strings.Join(keyPath, "."), stackName, err)
+}
+
+// findMergeConflictKeyPath returns the key path of the first value that can't be deep-merged with the value with the same key path
+// from the previous maps. A list can't override a map or a scalar value
+func findMergeConflictKeyPath(inputs []map[any]any) []string {
+	merged := map[any]any{}
+
+	for _, input := range inputs {
+		if keyPath := findMapMergeConflict(merged, input, nil); len(keyPath) > 0 {
+			return keyPath
+		}
+	}
+
+	return nil
+}
+
+// findMapMergeConflict deep-merges `src` into `dst` (without modifying `src`) and returns the key path of the first value
+// that can't be merged
+func findMapMergeConflict(dst map[any]any, src map[any]any, keyPath []string) []string {
+	keys := make([]string, 0, len(src))
+	srcKeys := map[string]any{}
+	for k := range src {
+		key := fmt.Sprintf("%v", k)
+		keys = append(keys, key)
+		srcKeys[key] = k
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		k := srcKeys[key]
+		srcValue := src[k]
+		dstValue, exists := dst[k]
+		currentKeyPath := append(append([]string{}, keyPath...), key)
+
+		srcMap, srcIsMap := srcValue.(map[any]any)
+		dstMap, dstIsMap := dstValue.(map[any]any)
+		_, srcIsList := srcValue.([]any)
+		_, dstIsList := dstValue.([]any)
+
+		switch {
+		case srcIsMap && dstIsMap:
+			if conflict := findMapMergeConflict(dstMap, srcMap, currentKeyPath); len(conflict) > 0 {
+				return conflict
+			}
+		case srcIsList && exists && dstValue != nil && !dstIsList:
+			return currentKeyPath
+		case srcIsMap:
+			dst[k] = findMapMergeConflictCopy(srcMap)
+		case srcValue != nil || !exists:
+			dst[k] = srcValue
+		}
+	}
+
+	return nil
+}
+
+// findMapMergeConflictCopy returns a copy of the map (including the nested maps), so merging into it does not modify the original map
+func findMapMergeConflictCopy(src map[any]any) map[any]any {
+	result := make(map[any]any, len(src))
+	for k, v := range src {
+		if vMap, ok := v.(map[any]any); ok {
+			result[k] = findMapMergeConflictCopy(vMap)
+		} else {
+			result[k] = v
+		}
+	}
+	return result
+}
+
+// jsonPointerToKeyPath converts a JSON pointer (e.g. `/components/terraform/vpc/vars`) to a key path
+func jsonPointerToKeyPath(pointer string) []string {
+	var keyPath []string
+
+	for _, p := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		if p == "" {
+			continue
+		}
+		p = strings.ReplaceAll(p, "~1", "/")
+		p = strings.ReplaceAll(p, "~0", "~")
+		keyPath = append(keyPath, p)
+	}
+
+	return keyPath
+}
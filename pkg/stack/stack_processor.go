@@ -3,7 +3,6 @@ package stack
 import (
 	"encoding/json"
 	"fmt"
-	"os"
 	"path"
 	"path/filepath"
 	"sort"
@@ -27,8 +26,43 @@ import (
 var (
 	// Mutex to serialize updates of the result map of ProcessYAMLConfigFiles function
 	processYAMLConfigFilesLock = &sync.Mutex{}
+
+	// Compiled Atmos manifest JSON Schemas (the schema is compiled once and used to validate all stack manifests)
+	compiledAtmosManifestJsonSchemas     = map[string]*jsonschema.Schema{}
+	compiledAtmosManifestJsonSchemasLock = &sync.Mutex{}
 )
 
+// compileAtmosManifestJsonSchema compiles the Atmos manifest JSON Schema from the file,
+// or the schema bundled with Atmos if the path is `cfg.EmbeddedAtmosManifestJsonSchemaPath`
+func compileAtmosManifestJsonSchema(atmosManifestJsonSchemaFilePath string) (*jsonschema.Schema, error) {
+	compiledAtmosManifestJsonSchemasLock.Lock()
+	defer compiledAtmosManifestJsonSchemasLock.Unlock()
+
+	if compiledSchema, ok := compiledAtmosManifestJsonSchemas[atmosManifestJsonSchemaFilePath]; ok {
+		return compiledSchema, nil
+	}
+
+	atmosManifestJsonSchema, err := cfg.GetAtmosManifestJsonSchema(atmosManifestJsonSchemaFilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	compiler := jsonschema.NewCompiler()
+	compiler.Draft = jsonschema.Draft2020
+
+	if err = compiler.AddResource(atmosManifestJsonSchemaFilePath, strings.NewReader(atmosManifestJsonSchema)); err != nil {
+		return nil, err
+	}
+
+	compiledSchema, err := compiler.Compile(atmosManifestJsonSchemaFilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	compiledAtmosManifestJsonSchemas[atmosManifestJsonSchemaFilePath] = compiledSchema
+	return compiledSchema, nil
+}
+
 // ProcessYAMLConfigFiles takes a list of paths to stack manifests, processes and deep-merges all imports,
 // and returns a list of stack configs
 func ProcessYAMLConfigFiles(
@@ -46,6 +80,9 @@ func ProcessYAMLConfigFiles(
 	error,
 ) {
 
+	// The imports of the stack manifests are collected again in each run
+	ResetStackManifestImports()
+
 	count := len(filePaths)
 	listResult := make([]string, count)
 	mapResult := map[string]any{}
@@ -63,12 +100,7 @@ func ProcessYAMLConfigFiles(
 				stackBasePath = path.Dir(p)
 			}
 
-			stackFileName := strings.TrimSuffix(
-				strings.TrimSuffix(
-					u.TrimBasePathFromPath(stackBasePath+"/", p),
-					cfg.DefaultStackConfigFileExtension),
-				".yml",
-			)
+			stackFileName := cfg.TrimStackConfigFileExtension(u.TrimBasePathFromPath(stackBasePath+"/", p))
 
 			deepMergedStackConfig, importsConfig, stackConfig, err := ProcessYAMLConfigFile(
 				stackBasePath,
@@ -183,21 +215,22 @@ func ProcessYAMLConfigFile(
 	// If we add a new stack manifest with some component configurations to the current branch, then the new file will not be present in
 	// the remote branch (with which the current branch is compared), and `atmos` would throw an error.
 	if err != nil && !ignoreMissingFiles {
-		return nil, nil, nil, err
+		return nil, nil, nil, newStackManifestError(basePath, filePath, nil, err)
 	}
 
 	// Process `Go` templates in the stack manifest using the provided context
 	if !skipTemplatesProcessingInImports && len(context) > 0 {
 		stackYamlConfig, err = u.ProcessTmpl(relativeFilePath, stackYamlConfig, context, ignoreMissingTemplateValues)
 		if err != nil {
-			return nil, nil, nil, err
+			return nil, nil, nil, newStackManifestError(basePath, filePath, nil, err)
 		}
 	}
 
-	stackConfigMap, err := c.YAMLToMapOfInterfaces(stackYamlConfig)
+	stackConfigMap, err := parseStackManifest(filePath, stackYamlConfig)
 	if err != nil {
 		e := fmt.Errorf("invalid stack manifest '%s'\n%v", relativeFilePath, err)
-		return nil, nil, nil, e
+		line, column := findErrorPosition(err)
+		return nil, nil, nil, &StackManifestError{File: relativeFilePath, Line: line, Column: column, ImportChain: []string{relativeFilePath}, Err: e}
 	}
 
 	// If the path to the Atmos manifest JSON Schema is provided, validate the stack manifest against it
@@ -214,22 +247,9 @@ func ProcessYAMLConfigFile(
 			return nil, nil, nil, err
 		}
 
-		compiler := jsonschema.NewCompiler()
-
 		atmosManifestJsonSchemaValidationErrorFormat := "Atmos manifest JSON Schema validation error in the file '%s':\n%v"
 
-		atmosManifestJsonSchemaFileReader, err := os.Open(atmosManifestJsonSchemaFilePath)
-		if err != nil {
-			return nil, nil, nil, errors.Errorf(atmosManifestJsonSchemaValidationErrorFormat, relativeFilePath, err)
-		}
-
-		if err := compiler.AddResource(atmosManifestJsonSchemaFilePath, atmosManifestJsonSchemaFileReader); err != nil {
-			return nil, nil, nil, errors.Errorf(atmosManifestJsonSchemaValidationErrorFormat, relativeFilePath, err)
-		}
-
-		compiler.Draft = jsonschema.Draft2020
-
-		compiledSchema, err := compiler.Compile(atmosManifestJsonSchemaFilePath)
+		compiledSchema, err := compileAtmosManifestJsonSchema(atmosManifestJsonSchemaFilePath)
 		if err != nil {
 			return nil, nil, nil, errors.Errorf(atmosManifestJsonSchemaValidationErrorFormat, relativeFilePath, err)
 		}
@@ -241,7 +261,13 @@ func ProcessYAMLConfigFile(
 				if err2 != nil {
 					return nil, nil, nil, errors.Errorf(atmosManifestJsonSchemaValidationErrorFormat, relativeFilePath, err2)
 				}
-				return nil, nil, nil, errors.Errorf(atmosManifestJsonSchemaValidationErrorFormat, relativeFilePath, string(b))
+				// Report the position of the first invalid section in the stack manifest
+				leafError := e
+				for len(leafError.Causes) > 0 {
+					leafError = leafError.Causes[0]
+				}
+				return nil, nil, nil, newStackManifestError(basePath, filePath, jsonPointerToKeyPath(leafError.InstanceLocation),
+					errors.Errorf(atmosManifestJsonSchemaValidationErrorFormat, relativeFilePath, string(b)))
 			default:
 				return nil, nil, nil, errors.Errorf(atmosManifestJsonSchemaValidationErrorFormat, relativeFilePath, err)
 			}
@@ -253,44 +279,51 @@ func ProcessYAMLConfigFile(
 	// Global overrides
 	if i, ok := stackConfigMap[cfg.OverridesSectionName]; ok {
 		if globalOverrides, ok = i.(map[any]any); !ok {
-			return nil, nil, nil, fmt.Errorf("invalid 'overrides' section in the stack manifest '%s'", relativeFilePath)
+			return nil, nil, nil, newStackManifestError(basePath, filePath, []string{cfg.OverridesSectionName},
+				fmt.Errorf("invalid 'overrides' section in the stack manifest '%s'", relativeFilePath))
 		}
 	}
 
 	// Terraform overrides
 	if o, ok := stackConfigMap["terraform"]; ok {
 		if globalTerraformSection, ok = o.(map[any]any); !ok {
-			return nil, nil, nil, fmt.Errorf("invalid 'terraform' section in the stack manifest '%s'", relativeFilePath)
+			return nil, nil, nil, newStackManifestError(basePath, filePath, []string{"terraform"},
+				fmt.Errorf("invalid 'terraform' section in the stack manifest '%s'", relativeFilePath))
 		}
 
 		if i, ok := globalTerraformSection[cfg.OverridesSectionName]; ok {
 			if terraformOverrides, ok = i.(map[any]any); !ok {
-				return nil, nil, nil, fmt.Errorf("invalid 'terraform.overrides' section in the stack manifest '%s'", relativeFilePath)
+				return nil, nil, nil, newStackManifestError(basePath, filePath, []string{"terraform", cfg.OverridesSectionName},
+					fmt.Errorf("invalid 'terraform.overrides' section in the stack manifest '%s'", relativeFilePath))
 			}
 		}
 	}
 
-	finalTerraformOverrides, err = m.Merge([]map[any]any{globalOverrides, terraformOverrides, parentTerraformOverrides})
+	finalTerraformOverrides, err = mergeStackSection([]string{"terraform", cfg.OverridesSectionName}, relativeFilePath,
+		[]map[any]any{globalOverrides, terraformOverrides, parentTerraformOverrides})
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, locateStackManifestError(basePath, filePath, err)
 	}
 
 	// Helmfile overrides
 	if o, ok := stackConfigMap["helmfile"]; ok {
 		if globalHelmfileSection, ok = o.(map[any]any); !ok {
-			return nil, nil, nil, fmt.Errorf("invalid 'helmfile' section in the stack manifest '%s'", relativeFilePath)
+			return nil, nil, nil, newStackManifestError(basePath, filePath, []string{"helmfile"},
+				fmt.Errorf("invalid 'helmfile' section in the stack manifest '%s'", relativeFilePath))
 		}
 
 		if i, ok := globalHelmfileSection[cfg.OverridesSectionName]; ok {
 			if helmfileOverrides, ok = i.(map[any]any); !ok {
-				return nil, nil, nil, fmt.Errorf("invalid 'terraform.overrides' section in the stack manifest '%s'", relativeFilePath)
+				return nil, nil, nil, newStackManifestError(basePath, filePath, []string{"helmfile", cfg.OverridesSectionName},
+					fmt.Errorf("invalid 'helmfile.overrides' section in the stack manifest '%s'", relativeFilePath))
 			}
 		}
 	}
 
-	finalHelmfileOverrides, err = m.Merge([]map[any]any{globalOverrides, helmfileOverrides, parentHelmfileOverrides})
+	finalHelmfileOverrides, err = mergeStackSection([]string{"helmfile", cfg.OverridesSectionName}, relativeFilePath,
+		[]map[any]any{globalOverrides, helmfileOverrides, parentHelmfileOverrides})
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, locateStackManifestError(basePath, filePath, err)
 	}
 
 	// Add the `overrides` section for all components in this manifest
@@ -323,45 +356,42 @@ func ProcessYAMLConfigFile(
 	// Find and process all imports
 	importStructs, err := processImportSection(stackConfigMap, relativeFilePath)
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, newStackManifestError(basePath, filePath, []string{"import"}, err)
 	}
 
+	// Absolute paths of all the imported files, used to find the import chain that led to the stack manifest with an error
+	var importFiles []string
+
 	for _, importStruct := range importStructs {
 		imp := importStruct.Path
 
 		if imp == "" {
-			return nil, nil, nil, fmt.Errorf("invalid empty import in the file '%s'", relativeFilePath)
-		}
-
-		// If the import file is specified without extension, use `.yaml` as default
-		impWithExt := imp
-		ext := filepath.Ext(imp)
-		if ext == "" {
-			ext = cfg.DefaultStackConfigFileExtension
-			impWithExt = imp + ext
+			return nil, nil, nil, newStackManifestError(basePath, filePath, []string{"import"},
+				fmt.Errorf("invalid empty import in the file '%s'", relativeFilePath))
 		}
 
-		impWithExtPath := path.Join(basePath, impWithExt)
+		// If the import file is specified without extension, the stack manifests with all the supported extensions are searched
+		impPath := path.Join(basePath, imp)
 
-		if impWithExtPath == filePath {
+		if impPath == filePath || (filepath.Ext(imp) == "" && impPath == cfg.TrimStackConfigFileExtension(filePath)) {
 			errorMessage := fmt.Sprintf("invalid import in the file '%s'\nThe file imports itself in '%s'",
 				relativeFilePath,
 				imp)
-			return nil, nil, nil, errors.New(errorMessage)
+			return nil, nil, nil, newStackManifestError(basePath, filePath, []string{"import"}, errors.New(errorMessage))
 		}
 
 		// Find all import matches in the glob
-		importMatches, err := u.GetGlobMatches(impWithExtPath)
+		importMatches, err := cfg.GetStackConfigFileGlobMatches(impPath)
 		if err != nil || len(importMatches) == 0 {
 			// Retry (b/c we are using `doublestar` library and it sometimes has issues reading many files in a Docker container)
 			// TODO: review `doublestar` library
 
-			importMatches, err = u.GetGlobMatches(impWithExtPath)
+			importMatches, err = cfg.GetStackConfigFileGlobMatches(impPath)
 			if err != nil || len(importMatches) == 0 {
 				// The import was not found -> check if the import is a Go template; if not, return the error
 				t, err2 := template.New(imp).Funcs(sprig.FuncMap()).Parse(imp)
 				if err2 != nil {
-					return nil, nil, nil, err2
+					return nil, nil, nil, newStackManifestError(basePath, filePath, []string{"import"}, err2)
 				}
 
 				isGoTemplate := false
@@ -382,13 +412,13 @@ func ProcessYAMLConfigFile(
 							relativeFilePath,
 							err,
 						)
-						return nil, nil, nil, errors.New(errorMessage)
+						return nil, nil, nil, newStackManifestError(basePath, filePath, []string{"import"}, errors.New(errorMessage))
 					} else if importMatches == nil {
 						errorMessage := fmt.Sprintf("no matches found for the import '%s' in the file '%s'",
 							imp,
 							relativeFilePath,
 						)
-						return nil, nil, nil, errors.New(errorMessage)
+						return nil, nil, nil, newStackManifestError(basePath, filePath, []string{"import"}, errors.New(errorMessage))
 					}
 				}
 			}
@@ -401,9 +431,11 @@ func ProcessYAMLConfigFile(
 		listOfMaps := []map[any]any{c.MapsOfStringsToMapsOfInterfaces(importStruct.Context), c.MapsOfStringsToMapsOfInterfaces(context)}
 		mergedContext, err := m.Merge(listOfMaps)
 		if err != nil {
-			return nil, nil, nil, err
+			return nil, nil, nil, newStackManifestError(basePath, filePath, []string{"import"}, err)
 		}
 
+		importFiles = append(importFiles, importMatches...)
+
 		for _, importFile := range importMatches {
 			yamlConfig, _, yamlConfigRaw, err := ProcessYAMLConfigFile(
 				basePath,
@@ -418,7 +450,7 @@ func ProcessYAMLConfigFile(
 				"",
 			)
 			if err != nil {
-				return nil, nil, nil, err
+				return nil, nil, nil, addImportToStackManifestError(basePath, filePath, err)
 			}
 
 			stackConfigs = append(stackConfigs, yamlConfig)
@@ -432,21 +464,24 @@ func ProcessYAMLConfigFile(
 		}
 	}
 
+	setStackManifestImports(filePath, importFiles)
+
 	if len(stackConfigMap) > 0 {
 		stackConfigs = append(stackConfigs, stackConfigMap)
 	}
 
 	// Deep-merge the stack manifest and all the imports
-	stackConfigsDeepMerged, err := m.Merge(stackConfigs)
+	stackConfigsDeepMerged, err := mergeStackSection(nil, relativeFilePath, stackConfigs)
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, locateStackManifestError(basePath, filePath, err)
 	}
 
 	return stackConfigsDeepMerged, importsConfig, stackConfigMap, nil
 }
 
 // ProcessStackConfig takes a stack manifest, deep-merges all variables, settings, environments and backends,
-// and returns the final stack configuration for all Terraform and helmfile components
+// and returns the final stack configuration for all Terraform and helmfile components.
+// The returned errors contain the position (`file:line:col`) of the invalid section in the stack manifest or its imports
 func ProcessStackConfig(
 	stacksBasePath string,
 	terraformComponentsBasePath string,
@@ -461,12 +496,41 @@ func ProcessStackConfig(
 	checkBaseComponentExists bool,
 ) (map[any]any, error) {
 
-	stackName := strings.TrimSuffix(
-		strings.TrimSuffix(
-			u.TrimBasePathFromPath(stacksBasePath+"/", stack),
-			cfg.DefaultStackConfigFileExtension),
-		".yml",
+	result, err := processStackConfig(
+		stacksBasePath,
+		terraformComponentsBasePath,
+		helmfileComponentsBasePath,
+		stack,
+		config,
+		processStackDeps,
+		processComponentDeps,
+		componentTypeFilter,
+		componentStackMap,
+		importsConfig,
+		checkBaseComponentExists,
 	)
+	if err != nil {
+		return nil, locateStackManifestError(stacksBasePath, stack, err)
+	}
+
+	return result, nil
+}
+
+func processStackConfig(
+	stacksBasePath string,
+	terraformComponentsBasePath string,
+	helmfileComponentsBasePath string,
+	stack string,
+	config map[any]any,
+	processStackDeps bool,
+	processComponentDeps bool,
+	componentTypeFilter string,
+	componentStackMap map[string]map[string][]string,
+	importsConfig map[string]map[any]any,
+	checkBaseComponentExists bool,
+) (map[any]any, error) {
+
+	stackName := cfg.TrimStackConfigFileExtension(u.TrimBasePathFromPath(stacksBasePath+"/", stack))
 
 	globalVarsSection := map[any]any{}
 	globalSettingsSection := map[any]any{}
@@ -547,7 +611,8 @@ func ProcessStackConfig(
 		}
 	}
 
-	globalAndTerraformVars, err := m.Merge([]map[any]any{globalVarsSection, terraformVars})
+	globalAndTerraformVars, err := mergeStackSection([]string{"terraform", "vars"}, stackName,
+		[]map[any]any{globalVarsSection, terraformVars})
 	if err != nil {
 		return nil, err
 	}
@@ -559,7 +624,8 @@ func ProcessStackConfig(
 		}
 	}
 
-	globalAndTerraformSettings, err := m.Merge([]map[any]any{globalSettingsSection, terraformSettings})
+	globalAndTerraformSettings, err := mergeStackSection([]string{"terraform", "settings"}, stackName,
+		[]map[any]any{globalSettingsSection, terraformSettings})
 	if err != nil {
 		return nil, err
 	}
@@ -571,7 +637,8 @@ func ProcessStackConfig(
 		}
 	}
 
-	globalAndTerraformEnv, err := m.Merge([]map[any]any{globalEnvSection, terraformEnv})
+	globalAndTerraformEnv, err := mergeStackSection([]string{"terraform", "env"}, stackName,
+		[]map[any]any{globalEnvSection, terraformEnv})
 	if err != nil {
 		return nil, err
 	}
@@ -627,7 +694,8 @@ func ProcessStackConfig(
 		}
 	}
 
-	globalAndHelmfileVars, err := m.Merge([]map[any]any{globalVarsSection, helmfileVars})
+	globalAndHelmfileVars, err := mergeStackSection([]string{"helmfile", "vars"}, stackName,
+		[]map[any]any{globalVarsSection, helmfileVars})
 	if err != nil {
 		return nil, err
 	}
@@ -639,7 +707,8 @@ func ProcessStackConfig(
 		}
 	}
 
-	globalAndHelmfileSettings, err := m.Merge([]map[any]any{globalSettingsSection, helmfileSettings})
+	globalAndHelmfileSettings, err := mergeStackSection([]string{"helmfile", "settings"}, stackName,
+		[]map[any]any{globalSettingsSection, helmfileSettings})
 	if err != nil {
 		return nil, err
 	}
@@ -651,7 +720,8 @@ func ProcessStackConfig(
 		}
 	}
 
-	globalAndHelmfileEnv, err := m.Merge([]map[any]any{globalEnvSection, helmfileEnv})
+	globalAndHelmfileEnv, err := mergeStackSection([]string{"helmfile", "env"}, stackName,
+		[]map[any]any{globalEnvSection, helmfileEnv})
 	if err != nil {
 		return nil, err
 	}
@@ -705,13 +775,17 @@ func ProcessStackConfig(
 				}
 
 				// Component metadata.
-				// This is per component, not deep-merged and not inherited from base components and globals.
+				// This is per component, not deep-merged and not inherited from base components and globals,
+				// except for the `enabled` and `enabled_when` attributes, which are inherited and can be set in `overrides`.
 				componentMetadata := map[any]any{}
 				if i, ok := componentMap["metadata"]; ok {
 					componentMetadata, ok = i.(map[any]any)
 					if !ok {
 						return nil, fmt.Errorf("invalid 'components.terraform.%s.metadata' section in the file '%s'", component, stackName)
 					}
+					if err = checkInheritableMetadata(componentMetadata); err != nil {
+						return nil, fmt.Errorf("invalid 'components.terraform.%s.metadata' section in the file '%s': %v", component, stackName, err)
+					}
 				}
 
 				// Component backend
@@ -763,6 +837,7 @@ func ProcessStackConfig(
 				componentOverridesVars := map[any]any{}
 				componentOverridesSettings := map[any]any{}
 				componentOverridesEnv := map[any]any{}
+				componentOverridesMetadata := map[any]any{}
 				componentOverridesTerraformCommand := ""
 
 				if i, ok := componentMap["overrides"]; ok {
@@ -788,6 +863,15 @@ func ProcessStackConfig(
 						}
 					}
 
+					if i, ok = componentOverrides["metadata"]; ok {
+						if componentOverridesMetadata, ok = i.(map[any]any); !ok {
+							return nil, fmt.Errorf("invalid 'components.terraform.%s.overrides.metadata' in the manifest '%s'", component, stackName)
+						}
+						if err = checkInheritableMetadata(componentOverridesMetadata); err != nil {
+							return nil, fmt.Errorf("invalid 'components.terraform.%s.overrides.metadata' in the manifest '%s': %v", component, stackName, err)
+						}
+					}
+
 					if i, ok = componentOverrides["command"]; ok {
 						if componentOverridesTerraformCommand, ok = i.(string); !ok {
 							return nil, fmt.Errorf("invalid 'components.terraform.%s.overrides.command' in the manifest '%s'", component, stackName)
@@ -913,7 +997,7 @@ func ProcessStackConfig(
 				sort.Strings(baseComponents)
 
 				// Final configs
-				finalComponentVars, err := m.Merge([]map[any]any{
+				finalComponentVars, err := mergeStackSection([]string{"components", "terraform", component, "vars"}, stackName, []map[any]any{
 					globalAndTerraformVars,
 					baseComponentVars,
 					componentVars,
@@ -923,7 +1007,7 @@ func ProcessStackConfig(
 					return nil, err
 				}
 
-				finalComponentSettings, err := m.Merge([]map[any]any{
+				finalComponentSettings, err := mergeStackSection([]string{"components", "terraform", component, "settings"}, stackName, []map[any]any{
 					globalAndTerraformSettings,
 					baseComponentSettings,
 					componentSettings,
@@ -933,7 +1017,7 @@ func ProcessStackConfig(
 					return nil, err
 				}
 
-				finalComponentEnv, err := m.Merge([]map[any]any{
+				finalComponentEnv, err := mergeStackSection([]string{"components", "terraform", component, "env"}, stackName, []map[any]any{
 					globalAndTerraformEnv,
 					baseComponentEnv,
 					componentEnv,
@@ -1079,6 +1163,13 @@ func ProcessStackConfig(
 					}
 				}
 
+				// Final metadata
+				finalComponentMetadata := mergeInheritableMetadata(
+					componentMetadata,
+					baseComponentConfig.BaseComponentMetadata,
+					componentOverridesMetadata,
+				)
+
 				comp := map[string]any{}
 				comp["vars"] = finalComponentVars
 				comp["settings"] = finalComponentSettings
@@ -1089,7 +1180,7 @@ func ProcessStackConfig(
 				comp["remote_state_backend"] = finalComponentRemoteStateBackend
 				comp["command"] = finalComponentTerraformCommand
 				comp["inheritance"] = componentInheritanceChain
-				comp["metadata"] = componentMetadata
+				comp["metadata"] = finalComponentMetadata
 				comp["overrides"] = componentOverrides
 
 				if baseComponentName != "" {
@@ -1143,13 +1234,17 @@ func ProcessStackConfig(
 				}
 
 				// Component metadata.
-				// This is per component, not deep-merged and not inherited from base components and globals.
+				// This is per component, not deep-merged and not inherited from base components and globals,
+				// except for the `enabled` and `enabled_when` attributes, which are inherited and can be set in `overrides`.
 				componentMetadata := map[any]any{}
 				if i, ok := componentMap["metadata"]; ok {
 					componentMetadata, ok = i.(map[any]any)
 					if !ok {
 						return nil, fmt.Errorf("invalid 'components.helmfile.%s.metadata' section in the file '%s'", component, stackName)
 					}
+					if err = checkInheritableMetadata(componentMetadata); err != nil {
+						return nil, fmt.Errorf("invalid 'components.helmfile.%s.metadata' section in the file '%s': %v", component, stackName, err)
+					}
 				}
 
 				componentHelmfileCommand := ""
@@ -1165,6 +1260,7 @@ func ProcessStackConfig(
 				componentOverridesVars := map[any]any{}
 				componentOverridesSettings := map[any]any{}
 				componentOverridesEnv := map[any]any{}
+				componentOverridesMetadata := map[any]any{}
 				componentOverridesHelmfileCommand := ""
 
 				if i, ok := componentMap["overrides"]; ok {
@@ -1190,6 +1286,15 @@ func ProcessStackConfig(
 						}
 					}
 
+					if i, ok = componentOverrides["metadata"]; ok {
+						if componentOverridesMetadata, ok = i.(map[any]any); !ok {
+							return nil, fmt.Errorf("invalid 'components.helmfile.%s.overrides.metadata' in the manifest '%s'", component, stackName)
+						}
+						if err = checkInheritableMetadata(componentOverridesMetadata); err != nil {
+							return nil, fmt.Errorf("invalid 'components.helmfile.%s.overrides.metadata' in the manifest '%s': %v", component, stackName, err)
+						}
+					}
+
 					if i, ok = componentOverrides["command"]; ok {
 						if componentOverridesHelmfileCommand, ok = i.(string); !ok {
 							return nil, fmt.Errorf("invalid 'components.helmfile.%s.overrides.command' in the manifest '%s'", component, stackName)
@@ -1304,7 +1409,7 @@ func ProcessStackConfig(
 				sort.Strings(baseComponents)
 
 				// Final configs
-				finalComponentVars, err := m.Merge([]map[any]any{
+				finalComponentVars, err := mergeStackSection([]string{"components", "helmfile", component, "vars"}, stackName, []map[any]any{
 					globalAndHelmfileVars,
 					baseComponentVars,
 					componentVars,
@@ -1314,7 +1419,7 @@ func ProcessStackConfig(
 					return nil, err
 				}
 
-				finalComponentSettings, err := m.Merge([]map[any]any{
+				finalComponentSettings, err := mergeStackSection([]string{"components", "helmfile", component, "settings"}, stackName, []map[any]any{
 					globalAndHelmfileSettings,
 					baseComponentSettings,
 					componentSettings,
@@ -1324,7 +1429,7 @@ func ProcessStackConfig(
 					return nil, err
 				}
 
-				finalComponentEnv, err := m.Merge([]map[any]any{
+				finalComponentEnv, err := mergeStackSection([]string{"components", "helmfile", component, "env"}, stackName, []map[any]any{
 					globalAndHelmfileEnv,
 					baseComponentEnv,
 					componentEnv,
@@ -1349,13 +1454,20 @@ func ProcessStackConfig(
 					finalComponentHelmfileCommand = componentOverridesHelmfileCommand
 				}
 
+				// Final metadata
+				finalComponentMetadata := mergeInheritableMetadata(
+					componentMetadata,
+					baseComponentConfig.BaseComponentMetadata,
+					componentOverridesMetadata,
+				)
+
 				comp := map[string]any{}
 				comp["vars"] = finalComponentVars
 				comp["settings"] = finalComponentSettings
 				comp["env"] = finalComponentEnv
 				comp["command"] = finalComponentHelmfileCommand
 				comp["inheritance"] = componentInheritanceChain
-				comp["metadata"] = componentMetadata
+				comp["metadata"] = finalComponentMetadata
 				comp["overrides"] = componentOverrides
 
 				if baseComponentName != "" {
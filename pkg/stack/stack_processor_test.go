@@ -1,11 +1,15 @@
 package stack
 
 import (
+	"errors"
+	"os"
+	"path"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/yaml.v2"
 
+	cfg "github.com/cloudposse/atmos/pkg/config"
 	c "github.com/cloudposse/atmos/pkg/convert"
 	u "github.com/cloudposse/atmos/pkg/utils"
 )
@@ -179,3 +183,303 @@ func TestStackProcessor(t *testing.T) {
 	assert.Nil(t, err)
 	t.Log(string(yamlConfig))
 }
+
+func TestStackProcessorInheritableMetadata(t *testing.T) {
+	manifest := `
+components:
+  terraform:
+    vpc-defaults:
+      metadata:
+        type: abstract
+        enabled_when:
+          stage: prod
+      vars:
+        stage: dev
+    vpc:
+      metadata:
+        component: vpc
+        inherits:
+          - vpc-defaults
+      vars: {}
+    vpc-disabled:
+      metadata:
+        component: vpc
+        enabled: false
+        inherits:
+          - vpc-defaults
+      overrides:
+        metadata:
+          enabled: true
+      vars: {}
+`
+	var config map[any]any
+	err := yaml.Unmarshal([]byte(manifest), &config)
+	assert.Nil(t, err)
+
+	res, err := ProcessStackConfig(
+		"../../examples/tests/stacks",
+		"../../examples/tests/components/terraform",
+		"../../examples/tests/components/helmfile",
+		"orgs/cp/tenant1/dev/us-east-2",
+		config,
+		false,
+		false,
+		"",
+		nil,
+		nil,
+		false,
+	)
+	assert.Nil(t, err)
+
+	terraformComponents := res["components"].(map[string]any)["terraform"].(map[string]any)
+
+	vpcMetadata := terraformComponents["vpc"].(map[string]any)["metadata"].(map[any]any)
+	assert.Equal(t, map[any]any{"stage": "prod"}, vpcMetadata["enabled_when"])
+	assert.Nil(t, vpcMetadata["type"])
+
+	vpcDisabledMetadata := terraformComponents["vpc-disabled"].(map[string]any)["metadata"].(map[any]any)
+	assert.Equal(t, true, vpcDisabledMetadata["enabled"])
+
+	config["components"].(map[any]any)["terraform"].(map[any]any)["vpc"].(map[any]any)["metadata"].(map[any]any)["enabled"] = "no"
+	_, err = ProcessStackConfig(
+		"../../examples/tests/stacks",
+		"../../examples/tests/components/terraform",
+		"../../examples/tests/components/helmfile",
+		"orgs/cp/tenant1/dev/us-east-2",
+		config,
+		false,
+		false,
+		"",
+		nil,
+		nil,
+		false,
+	)
+	assert.NotNil(t, err)
+}
+
+func TestStackManifestErrorPosition(t *testing.T) {
+	stacksBasePath := "../../examples/tests/stacks"
+
+	// The invalid attribute is reported at its position in the imported manifest, with the import chain
+	_, _, _, err := ProcessYAMLConfigFiles(
+		stacksBasePath,
+		"../../examples/tests/components/terraform",
+		"../../examples/tests/components/helmfile",
+		[]string{path.Join(stacksBasePath, "catalog/stack-manifest-errors/import-invalid-attribute.yaml")},
+		false,
+		false,
+		false,
+	)
+
+	var stackManifestError *StackManifestError
+	assert.True(t, errors.As(err, &stackManifestError))
+	assert.Equal(t, "catalog/stack-manifest-errors/invalid-attribute.yaml", stackManifestError.File)
+	assert.Equal(t, 8, stackManifestError.Line)
+	assert.Equal(t, 7, stackManifestError.Column)
+	assert.Equal(t, []string{
+		"catalog/stack-manifest-errors/import-invalid-attribute.yaml",
+		"catalog/stack-manifest-errors/invalid-attribute.yaml",
+	}, stackManifestError.ImportChain)
+	assert.Contains(t, err.Error(), "catalog/stack-manifest-errors/invalid-attribute.yaml:8:7: invalid 'components.terraform.vpc.backend_type' attribute")
+
+	// The YAML parser errors are reported with the line in the stack manifest
+	_, _, _, err = ProcessYAMLConfigFile(
+		stacksBasePath,
+		path.Join(stacksBasePath, "catalog/stack-manifest-errors/invalid-yaml.yaml"),
+		map[string]map[any]any{},
+		nil,
+		false,
+		false,
+		false,
+		map[any]any{},
+		map[any]any{},
+		"",
+	)
+
+	assert.True(t, errors.As(err, &stackManifestError))
+	assert.Equal(t, "catalog/stack-manifest-errors/invalid-yaml.yaml", stackManifestError.File)
+	assert.Greater(t, stackManifestError.Line, 0)
+	t.Log(err)
+}
+
+func TestStackManifestMergeErrorPosition(t *testing.T) {
+	stacksBasePath := "../../examples/tests/stacks"
+
+	// The list that can't override the map from the previous import is reported at its position in the imported manifest
+	_, _, _, err := ProcessYAMLConfigFiles(
+		stacksBasePath,
+		"../../examples/tests/components/terraform",
+		"../../examples/tests/components/helmfile",
+		[]string{path.Join(stacksBasePath, "catalog/stack-manifest-errors/import-merge-conflict.yaml")},
+		false,
+		false,
+		false,
+	)
+
+	var stackManifestError *StackManifestError
+	assert.True(t, errors.As(err, &stackManifestError))
+	assert.Equal(t, "catalog/stack-manifest-errors/merge-list.yaml", stackManifestError.File)
+	assert.Equal(t, 4, stackManifestError.Line)
+	assert.Equal(t, 3, stackManifestError.Column)
+	assert.Equal(t, []string{
+		"catalog/stack-manifest-errors/import-merge-conflict.yaml",
+		"catalog/stack-manifest-errors/merge-list.yaml",
+	}, stackManifestError.ImportChain)
+	assert.Contains(t, err.Error(), "catalog/stack-manifest-errors/merge-list.yaml:4:3: invalid 'vars.tags' attribute")
+
+	// The list in the component section that can't override the map from the global section is reported at its position
+	_, _, _, err = ProcessYAMLConfigFiles(
+		stacksBasePath,
+		"../../examples/tests/components/terraform",
+		"../../examples/tests/components/helmfile",
+		[]string{path.Join(stacksBasePath, "catalog/stack-manifest-errors/component-merge-conflict.yaml")},
+		false,
+		false,
+		false,
+	)
+
+	assert.True(t, errors.As(err, &stackManifestError))
+	assert.Equal(t, "catalog/stack-manifest-errors/component-merge-conflict.yaml", stackManifestError.File)
+	assert.Equal(t, 10, stackManifestError.Line)
+	assert.Equal(t, 9, stackManifestError.Column)
+	assert.Contains(t, err.Error(), "invalid 'components.terraform.vpc.vars.tags' attribute")
+}
+
+func TestFindMergeConflictKeyPath(t *testing.T) {
+	inputs := []map[any]any{
+		{"vars": map[any]any{"tags": map[any]any{"team": "platform"}, "zones": []any{"a"}}},
+		{"vars": map[any]any{"zones": []any{"b"}, "name": nil}},
+		{"vars": map[any]any{"tags": []any{"platform"}}},
+	}
+
+	assert.Equal(t, []string{"vars", "tags"}, findMergeConflictKeyPath(inputs))
+	assert.Nil(t, findMergeConflictKeyPath(inputs[:2]))
+
+	// The inputs are not modified
+	assert.Equal(t, map[any]any{"team": "platform"}, inputs[0]["vars"].(map[any]any)["tags"])
+	_, ok := inputs[0]["vars"].(map[any]any)["name"]
+	assert.False(t, ok)
+}
+
+func TestFindUnknownStackManifestSections(t *testing.T) {
+	stacksBasePath := "../../examples/tests/stacks"
+	filePath := path.Join(stacksBasePath, "catalog/strict/unknown-sections.yaml")
+
+	manifest, err := os.ReadFile(filePath)
+	assert.Nil(t, err)
+
+	var config map[any]any
+	err = yaml.Unmarshal(manifest, &config)
+	assert.Nil(t, err)
+
+	errs := FindUnknownStackManifestSections(stacksBasePath, filePath, config)
+	assert.Equal(t, 4, len(errs))
+
+	assert.Equal(t, "catalog/strict/unknown-sections.yaml:4:1: unknown section 'setings', did you mean 'settings'?", errs[0].Error())
+	assert.Equal(t, "catalog/strict/unknown-sections.yaml:8:3: unknown section 'backend_typ' in the 'terraform' section, "+
+		"did you mean 'backend_type'?", errs[1].Error())
+	assert.Equal(t, "catalog/strict/unknown-sections.yaml:16:7: unknown section 'foo' in the 'components.terraform.vpc' section", errs[2].Error())
+	assert.Equal(t, "catalog/strict/unknown-sections.yaml:14:7: unknown section 'var' in the 'components.terraform.vpc' section, "+
+		"did you mean 'vars'?", errs[3].Error())
+}
+
+func TestStackProcessorJSONAndHCLManifests(t *testing.T) {
+	stacksBasePath := "../../examples/tests/stacks"
+
+	_, mapResult, _, err := ProcessYAMLConfigFiles(
+		stacksBasePath,
+		"../../examples/tests/components/terraform",
+		"../../examples/tests/components/helmfile",
+		[]string{
+			path.Join(stacksBasePath, "catalog/formats/prod.hcl"),
+			path.Join(stacksBasePath, "catalog/formats/dev.yaml"),
+		},
+		false,
+		false,
+		false,
+	)
+	assert.Nil(t, err)
+
+	stack := mapResult["catalog/formats/prod"].(map[any]any)
+	assert.Equal(t, []string{"catalog/formats/eks", "catalog/formats/vpc"}, stack["imports"])
+
+	terraformComponents := stack["components"].(map[string]any)["terraform"].(map[string]any)
+
+	vpcVars := terraformComponents["vpc"].(map[string]any)["vars"].(map[any]any)
+	assert.Equal(t, "vpc", vpcVars["name"])
+	assert.Equal(t, 3, vpcVars["max_subnet_count"])
+	assert.Equal(t, "prod", vpcVars["stage"])
+
+	eksVars := terraformComponents["eks"].(map[string]any)["vars"].(map[any]any)
+	assert.Equal(t, "eks-prod", eksVars["name"])
+	assert.Equal(t, "us-east-2", eksVars["region"])
+
+	// The import without an extension uses the file with the highest precedence extension (`.yaml` over `.json`)
+	devVpcVars := mapResult["catalog/formats/dev"].(map[any]any)["components"].(map[string]any)["terraform"].(map[string]any)["vpc"].(map[string]any)["vars"].(map[any]any)
+	assert.Equal(t, "vpc-yaml", devVpcVars["name"])
+}
+
+func TestProcessYAMLConfigFileWithEmbeddedAtmosManifestJsonSchema(t *testing.T) {
+	stacksBasePath := "../../examples/tests/stacks"
+
+	_, _, _, err := ProcessYAMLConfigFile(
+		stacksBasePath,
+		path.Join(stacksBasePath, "catalog/invalid-yaml-and-schema/invalid-schema-2.yaml"),
+		map[string]map[any]any{},
+		nil,
+		false,
+		false,
+		false,
+		map[any]any{},
+		map[any]any{},
+		cfg.EmbeddedAtmosManifestJsonSchemaPath,
+	)
+
+	var stackManifestError *StackManifestError
+	assert.True(t, errors.As(err, &stackManifestError))
+	assert.Equal(t, "catalog/invalid-yaml-and-schema/invalid-schema-2.yaml", stackManifestError.File)
+	assert.Equal(t, 5, stackManifestError.Line)
+	assert.Contains(t, err.Error(), "Atmos manifest JSON Schema validation error")
+
+	// The valid stack manifests pass the validation
+	_, _, _, err = ProcessYAMLConfigFile(
+		stacksBasePath,
+		path.Join(stacksBasePath, "orgs/cp/tenant1/dev/us-east-2.yaml"),
+		map[string]map[any]any{},
+		nil,
+		false,
+		false,
+		false,
+		map[any]any{},
+		map[any]any{},
+		cfg.EmbeddedAtmosManifestJsonSchemaPath,
+	)
+	assert.Nil(t, err)
+}
+
+func TestProcessYAMLConfigFileWithDisabledComponents(t *testing.T) {
+	stacksBasePath := "../../examples/tests/stacks"
+
+	// The `metadata.enabled` and `metadata.enabled_when` attributes are allowed by all the copies of the Atmos manifest JSON Schema
+	schemas := []string{
+		cfg.EmbeddedAtmosManifestJsonSchemaPath,
+		"../../examples/quick-start/stacks/schemas/atmos/atmos-manifest/1.0/atmos-manifest.json",
+		"../../website/static/schemas/atmos/atmos-manifest/1.0/atmos-manifest.json",
+	}
+
+	for _, atmosManifestJsonSchemaFilePath := range schemas {
+		_, _, _, err := ProcessYAMLConfigFile(
+			stacksBasePath,
+			path.Join(stacksBasePath, "catalog/terraform/disabled-components.yaml"),
+			map[string]map[any]any{},
+			nil,
+			false,
+			false,
+			false,
+			map[any]any{},
+			map[any]any{},
+			atmosManifestJsonSchemaFilePath,
+		)
+		assert.Nil(t, err, atmosManifestJsonSchemaFilePath)
+	}
+}
@@ -22,6 +22,9 @@ import (
 
 var (
 	getFileContentSyncMap = sync.Map{}
+
+	// inheritableMetadataAttributes are the `metadata` attributes that are inherited from the base components and can be set in `overrides`
+	inheritableMetadataAttributes = []string{"enabled", "enabled_when"}
 )
 
 // FindComponentStacks finds all infrastructure stack manifests where the component or the base component is defined
@@ -262,9 +265,7 @@ func CreateComponentStackMap(
 				return err
 			}
 
-			isYaml := u.IsYaml(p)
-
-			if !isDirectory && isYaml {
+			if !isDirectory && cfg.IsStackConfigFile(p) {
 				config, _, _, err := ProcessYAMLConfigFile(
 					stacksBasePath,
 					p,
@@ -328,19 +329,36 @@ func CreateComponentStackMap(
 
 	for stack, components := range stackComponentMap["terraform"] {
 		for _, component := range components {
-			componentStackMap["terraform"][component] = append(componentStackMap["terraform"][component], strings.Replace(stack, cfg.DefaultStackConfigFileExtension, "", 1))
+			componentStackMap["terraform"][component] = append(componentStackMap["terraform"][component], cfg.TrimStackConfigFileExtension(stack))
 		}
 	}
 
 	for stack, components := range stackComponentMap["helmfile"] {
 		for _, component := range components {
-			componentStackMap["helmfile"][component] = append(componentStackMap["helmfile"][component], strings.Replace(stack, cfg.DefaultStackConfigFileExtension, "", 1))
+			componentStackMap["helmfile"][component] = append(componentStackMap["helmfile"][component], cfg.TrimStackConfigFileExtension(stack))
 		}
 	}
 
 	return componentStackMap, nil
 }
 
+// parseStackManifest parses the stack manifest according to the file extension: `.json` and `.hcl` files are parsed as JSON and HCL,
+// all other files are parsed as YAML. The extension of the templates (e.g. `vpc.json.tmpl`) is checked before the `.tmpl` suffix
+func parseStackManifest(filePath string, content string) (map[any]any, error) {
+	if strings.TrimSpace(content) == "" {
+		return nil, nil
+	}
+
+	switch filepath.Ext(strings.TrimSuffix(filePath, ".tmpl")) {
+	case ".json":
+		return c.JSONToYAMLMapOfInterfaces(content)
+	case ".hcl":
+		return c.HCLToMapOfInterfaces(filePath, content)
+	default:
+		return c.YAMLToMapOfInterfaces(content)
+	}
+}
+
 // getFileContent tries to read and return the file content from the sync map if it exists in the map,
 // otherwise it reads the file, stores its content in the map and returns the content
 func getFileContent(filePath string) (string, error) {
@@ -425,13 +443,17 @@ func ProcessBaseComponentConfig(
 		}
 
 		// Base component metadata.
-		// This is per component, not deep-merged and not inherited from base components and globals.
+		// This is per component, not deep-merged and not inherited from base components and globals,
+		// except for the `enabled` and `enabled_when` attributes.
 		componentMetadata := map[any]any{}
 		if i, ok := baseComponentMap["metadata"]; ok {
 			componentMetadata, ok = i.(map[any]any)
 			if !ok {
 				return fmt.Errorf("invalid '%s.metadata' section in the stack '%s'", component, stack)
 			}
+			if err := checkInheritableMetadata(componentMetadata); err != nil {
+				return fmt.Errorf("invalid '%s.metadata' section in the stack '%s': %v", baseComponent, stack, err)
+			}
 
 			if inheritList, inheritListExist := componentMetadata["inherits"].([]any); inheritListExist {
 				for _, v := range inheritList {
@@ -577,6 +599,9 @@ func ProcessBaseComponentConfig(
 		}
 		baseComponentConfig.BaseComponentRemoteStateBackendSection = merged
 
+		// Base component `metadata` (only the inheritable attributes)
+		baseComponentConfig.BaseComponentMetadata = getInheritableMetadata(baseComponentConfig.BaseComponentMetadata, componentMetadata)
+
 		baseComponentConfig.ComponentInheritanceChain = u.UniqueStrings(append([]string{baseComponent}, baseComponentConfig.ComponentInheritanceChain...))
 	} else {
 		if checkBaseComponentExists {
@@ -623,3 +648,159 @@ func FindComponentsDerivedFromBaseComponents(
 
 	return res, nil
 }
+
+// getInheritableMetadata returns the inheritable `metadata` attributes (`enabled` and `enabled_when`) from the provided sections.
+// The attributes are not deep-merged, the attributes from the later sections override the attributes from the earlier sections
+func getInheritableMetadata(sections ...map[any]any) map[any]any {
+	res := map[any]any{}
+
+	for _, section := range sections {
+		for _, attr := range inheritableMetadataAttributes {
+			if v, ok := section[attr]; ok {
+				res[attr] = v
+			}
+		}
+	}
+
+	return res
+}
+
+// mergeInheritableMetadata returns a copy of the component `metadata` section with the inheritable attributes
+// from the base components, the component itself, and the `overrides` section (in that order)
+func mergeInheritableMetadata(
+	componentMetadata map[any]any,
+	baseComponentMetadata map[any]any,
+	overridesMetadata map[any]any,
+) map[any]any {
+	res := map[any]any{}
+
+	for k, v := range componentMetadata {
+		res[k] = v
+	}
+
+	for k, v := range getInheritableMetadata(baseComponentMetadata, componentMetadata, overridesMetadata) {
+		res[k] = v
+	}
+
+	return res
+}
+
+// checkInheritableMetadata checks the types of the inheritable `metadata` attributes
+func checkInheritableMetadata(metadata map[any]any) error {
+	if i, ok := metadata["enabled"]; ok {
+		if _, ok = i.(bool); !ok {
+			return fmt.Errorf("'metadata.enabled' must be a boolean, but it's '%v'", i)
+		}
+	}
+
+	if i, ok := metadata["enabled_when"]; ok {
+		if _, ok = i.(map[any]any); !ok {
+			return fmt.Errorf("'metadata.enabled_when' must be a map of context variables to values, but it's '%v'", i)
+		}
+	}
+
+	return nil
+}
+
+var (
+	// Sections allowed in stack manifests, used to detect unknown (e.g. misspelled) sections in strict mode
+	globalSections = []string{
+		cfg.ImportSectionName, "vars", "settings", "env", "terraform", "helmfile", "components", cfg.OverridesSectionName,
+		"workflows",
+	}
+	terraformSections = []string{
+		"vars", "settings", "env", "command", "backend_type", "backend", "remote_state_backend_type", "remote_state_backend",
+		cfg.OverridesSectionName,
+	}
+	helmfileSections = []string{
+		"vars", "settings", "env", "command", cfg.OverridesSectionName,
+	}
+	componentTypeSections = []string{
+		"terraform", "helmfile",
+	}
+	terraformComponentSections = []string{
+		"vars", "settings", "env", "metadata", "component", "command", "backend_type", "backend",
+		"remote_state_backend_type", "remote_state_backend", cfg.OverridesSectionName,
+	}
+	helmfileComponentSections = []string{
+		"vars", "settings", "env", "metadata", "component", "command", cfg.OverridesSectionName,
+	}
+)
+
+// FindUnknownStackManifestSections checks the global, `terraform`, `helmfile` and component sections in the stack manifest,
+// and returns an error (with the position of the section in the stack manifest and a "did you mean" suggestion) for each unknown section
+func FindUnknownStackManifestSections(basePath string, filePath string, stackConfig map[any]any) []error {
+	var result []error
+
+	check := func(section map[any]any, sectionPath []string, allowedSections []string) {
+		var keys []string
+		for k := range section {
+			keys = append(keys, fmt.Sprintf("%v", k))
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			if u.SliceContainsString(allowedSections, k) {
+				continue
+			}
+
+			keyPath := append(append([]string{}, sectionPath...), k)
+
+			var errorMessage string
+			if len(sectionPath) == 0 {
+				errorMessage = fmt.Sprintf("unknown section '%s'", k)
+			} else {
+				errorMessage = fmt.Sprintf("unknown section '%s' in the '%s' section", k, strings.Join(sectionPath, "."))
+			}
+
+			if suggestion := u.FindClosestString(k, allowedSections, 2); suggestion != "" {
+				errorMessage += fmt.Sprintf(", did you mean '%s'?", suggestion)
+			}
+
+			result = append(result, newStackManifestError(basePath, filePath, keyPath, errors.New(errorMessage)))
+		}
+	}
+
+	check(stackConfig, nil, globalSections)
+
+	if terraformSection, ok := stackConfig["terraform"].(map[any]any); ok {
+		check(terraformSection, []string{"terraform"}, terraformSections)
+	}
+
+	if helmfileSection, ok := stackConfig["helmfile"].(map[any]any); ok {
+		check(helmfileSection, []string{"helmfile"}, helmfileSections)
+	}
+
+	componentsSection, ok := stackConfig["components"].(map[any]any)
+	if !ok {
+		return result
+	}
+
+	check(componentsSection, []string{"components"}, componentTypeSections)
+
+	componentSections := map[string][]string{
+		"terraform": terraformComponentSections,
+		"helmfile":  helmfileComponentSections,
+	}
+
+	for _, componentType := range componentTypeSections {
+		componentTypeSection, ok := componentsSection[componentType].(map[any]any)
+		if !ok {
+			continue
+		}
+
+		var components []string
+		for k := range componentTypeSection {
+			components = append(components, fmt.Sprintf("%v", k))
+		}
+		sort.Strings(components)
+
+		for _, component := range components {
+			if componentSection, ok := componentTypeSection[component].(map[any]any); ok {
+				check(componentSection, []string{"components", componentType, component}, componentSections[componentType])
+			}
+		}
+	}
+
+	return result
+}
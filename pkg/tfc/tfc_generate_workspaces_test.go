@@ -0,0 +1,79 @@
+package tfc
+
+import (
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	e "github.com/cloudposse/atmos/internal/exec"
+	cfg "github.com/cloudposse/atmos/pkg/config"
+	"github.com/cloudposse/atmos/pkg/schema"
+	u "github.com/cloudposse/atmos/pkg/utils"
+)
+
+func TestTfcGenerateWorkspaces(t *testing.T) {
+	cliConfig, err := cfg.InitCliConfig(schema.ConfigAndStacksInfo{}, true)
+	assert.Nil(t, err)
+
+	terraformJson, err := e.ExecuteTfcGenerateWorkspaces(cliConfig, "acme")
+	assert.Nil(t, err)
+
+	resources := terraformJson["resource"].(map[string]any)
+
+	// The workspace name from `settings.tfc.workspace_name_pattern`
+	workspaces := resources["tfe_workspace"].(map[string]any)
+	workspace := workspaces["tenant1-ue2-test-1-top-level-component2"].(map[string]any)
+	err = u.PrintAsYAML(workspace)
+	assert.Nil(t, err)
+
+	assert.Equal(t, "tenant1-ue2-test-1-top-level-component2", workspace["name"])
+	assert.Equal(t, "acme", workspace["organization"])
+	assert.Equal(t, "components/terraform/top-level-component1", workspace["working_directory"])
+	assert.Equal(t, "1.5.7", workspace["terraform_version"])
+	assert.Equal(t, false, workspace["auto_apply"])
+	assert.Equal(t, []any{"atmos"}, workspace["tag_names"])
+
+	// The default workspace name `<stack>-<component>`
+	_, ok := workspaces["tenant1-ue2-test-1-test-test2-test-component-2"]
+	assert.True(t, ok)
+
+	// The components without `settings.tfc.workspace_enabled: true` don't have workspaces
+	_, ok = workspaces["tenant1-ue2-test-1-top-level-component1"]
+	assert.False(t, ok)
+
+	// The string vars are passed as is, the other vars are HCL-encoded
+	variables := resources["tfe_variable"].(map[string]any)
+
+	variable := variables["tenant1-ue2-test-1-top-level-component2_var_stage"].(map[string]any)
+	assert.Equal(t, "stage", variable["key"])
+	assert.Equal(t, "test-1", variable["value"])
+	assert.Equal(t, false, variable["hcl"])
+	assert.Equal(t, "terraform", variable["category"])
+	assert.Equal(t, "${tfe_workspace.tenant1-ue2-test-1-top-level-component2.id}", variable["workspace_id"])
+
+	variable = variables["tenant1-ue2-test-1-top-level-component2_var_enabled"].(map[string]any)
+	assert.Equal(t, "true", variable["value"])
+	assert.Equal(t, true, variable["hcl"])
+
+	// The run triggers from the workspaces of the components in `settings.depends_on`
+	runTriggers := resources["tfe_run_trigger"].(map[string]any)
+	runTrigger := runTriggers["tenant1-ue2-test-1-top-level-component2__tenant1-ue2-test-1-test-test2-test-component-2"].(map[string]any)
+	assert.Equal(t, "${tfe_workspace.tenant1-ue2-test-1-top-level-component2.id}", runTrigger["workspace_id"])
+	assert.Equal(t, "${tfe_workspace.tenant1-ue2-test-1-test-test2-test-component-2.id}", runTrigger["sourceable_id"])
+	assert.Equal(t, 1, len(runTriggers))
+}
+
+func TestTfcGenerateWorkspacesDuplicateResourceNames(t *testing.T) {
+	cliConfig, err := cfg.InitCliConfig(schema.ConfigAndStacksInfo{}, true)
+	assert.Nil(t, err)
+
+	cliConfig.StackConfigFilesAbsolutePaths = []string{
+		path.Join(cliConfig.StacksBaseAbsolutePath, "catalog/tfc-errors/duplicate-resource-names.yaml"),
+	}
+
+	_, err = e.ExecuteTfcGenerateWorkspaces(cliConfig, "acme")
+	assert.NotNil(t, err)
+	assert.Equal(t, "the TFC workspaces 'acme.vpc' and 'acme_vpc' have the same Terraform resource name 'acme_vpc'. "+
+		"Check the 'workspace_name' and 'workspace_name_pattern' attributes in the 'settings.tfc' section", err.Error())
+}
@@ -10,6 +10,7 @@ import (
 	jsonParser "github.com/hashicorp/hcl/json/parser"
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
 
 	"github.com/cloudposse/atmos/pkg/convert"
 )
@@ -41,7 +42,7 @@ func WriteToFileAsHcl(
 		return err
 	}
 
-	f, err := os.OpenFile(filePath, os.O_WRONLY|os.O_CREATE, fileMode)
+	f, err := os.OpenFile(filePath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, fileMode)
 	if err != nil {
 		return err
 	}
@@ -86,6 +87,26 @@ func ConvertToHclAst(data any) (ast.Node, error) {
 	return astree.Node, nil
 }
 
+// ConvertToHclExpression converts the provided value to an HCL expression (e.g. `["a", "b"]` or `{ a = 1 }`)
+func ConvertToHclExpression(data any) (string, error) {
+	j, err := ConvertToJSONFast(data)
+	if err != nil {
+		return "", err
+	}
+
+	ctyType, err := ctyjson.ImpliedType([]byte(j))
+	if err != nil {
+		return "", err
+	}
+
+	ctyValue, err := ctyjson.Unmarshal([]byte(j), ctyType)
+	if err != nil {
+		return "", err
+	}
+
+	return string(hclwrite.TokensForValue(ctyValue).Bytes()), nil
+}
+
 // WriteTerraformBackendConfigToFileAsHcl writes the provided Terraform backend config to the specified file
 // https://dev.to/pdcommunity/write-terraform-files-in-go-with-hclwrite-2e1j
 // https://pkg.go.dev/github.com/hashicorp/hcl/v2/hclwrite
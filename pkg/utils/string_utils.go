@@ -14,3 +14,47 @@ func UniqueStrings(input []string) []string {
 
 	return u
 }
+
+// LevenshteinDistance returns the edit distance between two strings
+// (the minimum number of single-character insertions, deletions and substitutions required to change one string into the other)
+func LevenshteinDistance(a string, b string) int {
+	ra := []rune(a)
+	rb := []rune(b)
+
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(rb)]
+}
+
+// FindClosestString returns the string from the candidates with the smallest edit distance to the provided string.
+// If none of the candidates is within the max distance, it returns an empty string
+func FindClosestString(s string, candidates []string, maxDistance int) string {
+	closest := ""
+	closestDistance := maxDistance + 1
+
+	for _, c := range candidates {
+		if d := LevenshteinDistance(s, c); d < closestDistance {
+			closest = c
+			closestDistance = d
+		}
+	}
+
+	return closest
+}
@@ -0,0 +1,14 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLevenshteinDistance(t *testing.T) {
+	assert.Equal(t, 1, LevenshteinDistance("setings", "settings"))
+	assert.Equal(t, 3, LevenshteinDistance("kitten", "sitting"))
+	assert.Equal(t, "components", FindClosestString("component", []string{"vars", "components"}, 2))
+	assert.Equal(t, "", FindClosestString("foo", []string{"vars", "components"}, 2))
+}
@@ -2,8 +2,10 @@ package utils
 
 import (
 	"os"
+	"strconv"
 
 	"gopkg.in/yaml.v2"
+	yamlv3 "gopkg.in/yaml.v3"
 )
 
 // PrintAsYAML prints the provided value as YAML document to the console
@@ -37,3 +39,65 @@ func ConvertToYAML(data any) (string, error) {
 	}
 	return string(y), nil
 }
+
+// ParseYAMLNode parses the provided YAML content into a YAML node tree, which keeps the positions (line and column) of all the keys and values
+func ParseYAMLNode(content string) (*yamlv3.Node, error) {
+	var root yamlv3.Node
+
+	if err := yamlv3.Unmarshal([]byte(content), &root); err != nil {
+		return nil, err
+	}
+
+	return &root, nil
+}
+
+// FindYAMLKeyPosition returns the line and column of the key at the provided path in the YAML node tree
+// (e.g. `components.terraform.vpc.vars.name` is passed as `[]string{"components", "terraform", "vpc", "vars", "name"}`).
+// The items in the lists are referenced by index (e.g. `[]string{"commands", "0", "name"}`).
+// If the key is not found, it returns `0, 0`
+func FindYAMLKeyPosition(root *yamlv3.Node, keyPath []string) (int, int) {
+	if root == nil || len(root.Content) == 0 {
+		return 0, 0
+	}
+
+	node := root.Content[0]
+
+	for i, key := range keyPath {
+		// The list items don't have keys, the position of the item is returned
+		if node.Kind == yamlv3.SequenceNode {
+			index, err := strconv.Atoi(key)
+			if err != nil || index < 0 || index >= len(node.Content) {
+				return 0, 0
+			}
+			node = node.Content[index]
+			if i == len(keyPath)-1 {
+				return node.Line, node.Column
+			}
+			continue
+		}
+
+		if node.Kind != yamlv3.MappingNode {
+			return 0, 0
+		}
+
+		found := false
+
+		// The content of a mapping node is a list of key and value nodes
+		for j := 0; j+1 < len(node.Content); j += 2 {
+			if node.Content[j].Value == key {
+				if i == len(keyPath)-1 {
+					return node.Content[j].Line, node.Content[j].Column
+				}
+				node = node.Content[j+1]
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return 0, 0
+		}
+	}
+
+	return 0, 0
+}
@@ -1,9 +1,16 @@
 package validate
 
 import (
-	"github.com/stretchr/testify/assert"
+	"encoding/json"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	cp "github.com/otiai10/copy"
+	"github.com/stretchr/testify/assert"
+
 	e "github.com/cloudposse/atmos/internal/exec"
 	cfg "github.com/cloudposse/atmos/pkg/config"
 	"github.com/cloudposse/atmos/pkg/schema"
@@ -86,3 +93,291 @@ func TestValidateComponent4(t *testing.T) {
 	assert.Error(t, err)
 	assert.Equal(t, "'service_1_name' variable length must be greater than 10 chars", err.Error())
 }
+
+func TestValidateComponentWithCue(t *testing.T) {
+	info := schema.ConfigAndStacksInfo{}
+
+	cliConfig, err := cfg.InitCliConfig(info, true)
+	assert.Nil(t, err)
+
+	ok, err := e.ExecuteValidateComponent(
+		cliConfig,
+		info,
+		"infra/vpc",
+		"tenant1-ue2-dev",
+		"vpc/validate-infra-vpc-component.cue",
+		"cue",
+		nil,
+		0)
+	u.LogError(err)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+}
+
+func TestValidateWithCue(t *testing.T) {
+	data := map[string]any{
+		"vars": map[any]any{
+			"region":                  "us-east-2",
+			"ipv4_primary_cidr_block": "10.10.0.0/33",
+			"map_public_ip_on_launch": "true",
+			"max_subnet_count":        10,
+		},
+	}
+
+	ok, err := e.ValidateWithCue(
+		data,
+		"../../examples/tests/stacks/schemas/cue/vpc/validate-infra-vpc-component.cue",
+		"../../examples/tests/stacks/schemas/cue",
+	)
+	u.LogError(err)
+	assert.False(t, ok)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "vars.ipv4_primary_cidr_block: invalid value")
+	assert.Contains(t, err.Error(), "vars.map_public_ip_on_launch: conflicting values")
+	assert.Contains(t, err.Error(), "vars.max_subnet_count: invalid value 10")
+}
+
+func TestValidateComponents(t *testing.T) {
+	info := schema.ConfigAndStacksInfo{}
+
+	cliConfig, err := cfg.InitCliConfig(info, true)
+	assert.Nil(t, err)
+
+	results, err := e.ExecuteValidateComponents(
+		cliConfig,
+		[]string{"tenant1-ue2-dev"},
+		[]string{"infra/vpc"},
+		2,
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(results))
+
+	// The results are sorted by policy, component and stack
+	assert.Equal(t, "check-infra-vpc-component-config-with-opa-policy", results[0].Policy)
+	assert.Equal(t, "opa", results[0].SchemaType)
+	assert.Equal(t, "infra/vpc", results[0].Component)
+	assert.Equal(t, "tenant1-ue2-dev", results[0].Stack)
+	assert.False(t, results[0].Passed)
+	assert.Contains(t, results[0].Message, "only 2 Availability Zones are allowed")
+
+	assert.Equal(t, "validate-infra-vpc-component-with-jsonschema", results[1].Policy)
+	assert.Equal(t, "jsonschema", results[1].SchemaType)
+}
+
+func TestValidateComponentsPolicyViolationPosition(t *testing.T) {
+	info := schema.ConfigAndStacksInfo{}
+
+	cliConfig, err := cfg.InitCliConfig(info, true)
+	assert.Nil(t, err)
+
+	results, err := e.ExecuteValidateComponents(
+		cliConfig,
+		[]string{"tenant1-ue2-dev"},
+		[]string{"infra/vpc"},
+		2,
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(results))
+
+	// The structured policy violations are located in the stack manifests where the values are defined
+	result := results[2]
+	assert.Equal(t, "validate-infra-vpc-component-with-structured-opa-policy", result.Policy)
+	assert.False(t, result.Passed)
+	assert.Equal(t, 1, len(result.Violations))
+	assert.Equal(t, "vars.availability_zones", result.Violations[0].Path)
+	assert.Equal(t, "orgs/cp/tenant1/dev/us-east-2", result.Violations[0].StackFile)
+	assert.Equal(t, 28, result.Violations[0].Line)
+
+	// The SARIF results contain the region with the line
+	file := path.Join(t.TempDir(), "validation.sarif")
+	err = e.WriteComponentValidationReport(cliConfig, results, "sarif", file)
+	assert.Nil(t, err)
+
+	content, err := os.ReadFile(file)
+	assert.Nil(t, err)
+
+	var report struct {
+		Runs []struct {
+			Results []struct {
+				RuleId    string `json:"ruleId"`
+				Locations []struct {
+					PhysicalLocation struct {
+						ArtifactLocation struct {
+							Uri string `json:"uri"`
+						} `json:"artifactLocation"`
+						Region *struct {
+							StartLine int `json:"startLine"`
+						} `json:"region"`
+					} `json:"physicalLocation"`
+				} `json:"locations"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	err = json.Unmarshal(content, &report)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(report.Runs))
+
+	found := false
+	for _, r := range report.Runs[0].Results {
+		if r.RuleId != "validate-infra-vpc-component-with-structured-opa-policy" || len(r.Locations) == 0 {
+			continue
+		}
+		location := r.Locations[0].PhysicalLocation
+		if location.Region != nil && location.Region.StartLine == 28 {
+			found = true
+			assert.True(t, strings.HasSuffix(location.ArtifactLocation.Uri, "orgs/cp/tenant1/dev/us-east-2.yaml"))
+		}
+	}
+	assert.True(t, found)
+}
+
+func TestValidateComponentWithStructuredOpaPolicy(t *testing.T) {
+	info := schema.ConfigAndStacksInfo{}
+
+	cliConfig, err := cfg.InitCliConfig(info, true)
+	assert.Nil(t, err)
+
+	_, err = e.ExecuteValidateComponent(
+		cliConfig,
+		info,
+		"infra/vpc",
+		"tenant1-ue2-dev",
+		"vpc/validate-infra-vpc-component-structured.rego",
+		"opa",
+		nil,
+		0)
+	u.LogError(err)
+	assert.Error(t, err)
+
+	// The error is mapped to the stack manifest where the value is defined
+	assert.Contains(t, err.Error(), "[vpc-dev-availability-zones] [high] In 'dev', only 2 Availability Zones are allowed")
+	assert.Contains(t, err.Error(), "path: vars.availability_zones, file: orgs/cp/tenant1/dev/us-east-2:")
+
+	// The error with the severity lower than 'fail_on_severity' is reported as a warning
+	assert.NotContains(t, err.Error(), "NAT Gateways")
+}
+
+func TestEvaluateOpaPolicy(t *testing.T) {
+	data := map[string]any{
+		"vars": map[string]any{
+			"stage":                   "dev",
+			"availability_zones":      []string{"us-east-2a", "us-east-2b", "us-east-2c"},
+			"nat_gateway_enabled":     true,
+			"map_public_ip_on_launch": true,
+		},
+	}
+
+	result, err := e.EvaluateOpaPolicy(data, "../../examples/tests/stacks/schemas/opa/vpc/validate-infra-vpc-component-structured.rego", nil, 0)
+	assert.Nil(t, err)
+
+	assert.Equal(t, "medium", result.FailOnSeverity)
+	assert.Equal(t, 2, len(result.Errors))
+
+	assert.Equal(t, 1, len(result.Warnings))
+	assert.Equal(t, "Mapping public IPs on launch is not recommended", result.Warnings[0].Message)
+	assert.Equal(t, "vars.map_public_ip_on_launch", result.Warnings[0].Path)
+	assert.Equal(t, "vpc-map-public-ip-on-launch", result.Warnings[0].RuleId)
+}
+
+func TestValidateComponentWithValidationCache(t *testing.T) {
+	info := schema.ConfigAndStacksInfo{}
+
+	cliConfig, err := cfg.InitCliConfig(info, true)
+	assert.Nil(t, err)
+
+	// Copy the schemas to a temp folder to be able to change the policies and the modules
+	schemasDir := t.TempDir()
+	err = cp.Copy(path.Join(cliConfig.BasePath, "stacks/schemas"), schemasDir)
+	assert.Nil(t, err)
+
+	basePathAbs, err := filepath.Abs(cliConfig.BasePath)
+	assert.Nil(t, err)
+	schemasRelPath, err := filepath.Rel(basePathAbs, schemasDir)
+	assert.Nil(t, err)
+	cliConfig.Schemas.Cue.BasePath = path.Join(schemasRelPath, "cue")
+	cliConfig.Schemas.Opa.BasePath = path.Join(schemasRelPath, "opa")
+
+	cacheDir := t.TempDir()
+	cliConfig.Validate.Cache.Path = cacheDir
+
+	assertCacheFiles := func(count int) []os.DirEntry {
+		cacheFiles, err := os.ReadDir(cacheDir)
+		assert.Nil(t, err)
+		assert.Equal(t, count, len(cacheFiles))
+		return cacheFiles
+	}
+
+	// The successful validation result is cached
+	ok, err := e.ExecuteValidateComponent(cliConfig, info, "infra/vpc", "tenant1-ue2-dev", "vpc/validate-infra-vpc-component.cue", "cue", nil, 0)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	cacheFile := path.Join(cacheDir, assertCacheFiles(1)[0].Name())
+
+	// The cached result is used, and the policy is not evaluated again (the cache file is not re-written)
+	cachedEntry := []byte(`{"schema_path": "vpc/validate-infra-vpc-component.cue", "schema_type": "cue", "created_at": "2000-01-01T00:00:00Z"}`)
+	err = os.WriteFile(cacheFile, cachedEntry, 0644)
+	assert.Nil(t, err)
+
+	ok, err = e.ExecuteValidateComponent(cliConfig, info, "infra/vpc", "tenant1-ue2-dev", "vpc/validate-infra-vpc-component.cue", "cue", nil, 0)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	content, err := os.ReadFile(cacheFile)
+	assert.Nil(t, err)
+	assert.Equal(t, cachedEntry, content)
+	assertCacheFiles(1)
+
+	// The component with different vars produces a new cache key
+	ok, err = e.ExecuteValidateComponent(cliConfig, info, "infra/vpc", "tenant1-ue2-prod", "vpc/validate-infra-vpc-component.cue", "cue", nil, 0)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assertCacheFiles(2)
+
+	// Changing the policy file produces a new cache key
+	policyFile := path.Join(schemasDir, "cue/vpc/validate-infra-vpc-component.cue")
+	appendToFile(t, policyFile, "\n// The policy was changed\n")
+
+	ok, err = e.ExecuteValidateComponent(cliConfig, info, "infra/vpc", "tenant1-ue2-dev", "vpc/validate-infra-vpc-component.cue", "cue", nil, 0)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assertCacheFiles(3)
+
+	// Changing a module file produces a new cache key
+	ok, err = e.ExecuteValidateComponent(cliConfig, info, "infra/vpc", "tenant1-ue2-staging", "vpc/validate-infra-vpc-component.rego", "opa", []string{"catalog"}, 0)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assertCacheFiles(4)
+
+	moduleFile := path.Join(schemasDir, "opa/catalog/constants/constants.rego")
+	appendToFile(t, moduleFile, "\n# The module was changed\n")
+
+	ok, err = e.ExecuteValidateComponent(cliConfig, info, "infra/vpc", "tenant1-ue2-staging", "vpc/validate-infra-vpc-component.rego", "opa", []string{"catalog"}, 0)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assertCacheFiles(5)
+
+	// The failed validation result is not cached
+	_, err = e.ExecuteValidateComponent(cliConfig, info, "infra/vpc", "tenant1-ue2-dev", "vpc/validate-infra-vpc-component.rego", "opa", []string{"catalog"}, 0)
+	assert.Error(t, err)
+	assertCacheFiles(5)
+
+	// The cache is not used if it's disabled
+	cliConfig.Validate.Cache.Disabled = true
+	cacheDir = t.TempDir()
+	cliConfig.Validate.Cache.Path = cacheDir
+
+	ok, err = e.ExecuteValidateComponent(cliConfig, info, "infra/vpc", "tenant1-ue2-dev", "vpc/validate-infra-vpc-component.cue", "cue", nil, 0)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assertCacheFiles(0)
+}
+
+func appendToFile(t *testing.T, file string, content string) {
+	f, err := os.OpenFile(file, os.O_APPEND|os.O_WRONLY, 0644)
+	assert.Nil(t, err)
+	defer f.Close()
+
+	_, err = f.WriteString(content)
+	assert.Nil(t, err)
+}
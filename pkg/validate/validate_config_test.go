@@ -0,0 +1,63 @@
+package validate
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	e "github.com/cloudposse/atmos/internal/exec"
+	cfg "github.com/cloudposse/atmos/pkg/config"
+	"github.com/cloudposse/atmos/pkg/schema"
+	u "github.com/cloudposse/atmos/pkg/utils"
+)
+
+func TestValidateConfig(t *testing.T) {
+	err := e.ExecuteValidateConfig([]string{
+		"../../examples/tests/atmos.yaml",
+		"../../examples/quick-start/atmos.yaml",
+		"atmos.yaml",
+	})
+	assert.Nil(t, err)
+
+	err = e.ExecuteValidateConfig([]string{"atmos.yaml", "../../examples/tests/cli-config/invalid/atmos.yaml"})
+	u.LogError(err)
+	assert.NotNil(t, err)
+}
+
+func TestValidateCliConfigFiles(t *testing.T) {
+	file := "../../examples/tests/cli-config/invalid/atmos.yaml"
+
+	errs, err := cfg.ValidateCliConfigFiles([]string{file})
+	assert.Nil(t, err)
+	assert.Equal(t, 5, len(errs))
+
+	var cliConfigError *cfg.CliConfigError
+	assert.True(t, errors.As(errs[0], &cliConfigError))
+	assert.Equal(t, file, cliConfigError.File)
+	assert.Equal(t, 8, cliConfigError.Line)
+
+	assert.Equal(t, file+":8:5: unknown key 'auto_generate_backend_files' in the 'components.terraform' section, "+
+		"did you mean 'auto_generate_backend_file'?", errs[0].Error())
+	assert.Equal(t, file+":9:5: invalid 'components.terraform.apply_auto_approve': expected boolean, but got string", errs[1].Error())
+	assert.Equal(t, file+":13:3: unknown key 'name_patern' in the 'stacks' section, did you mean 'name_pattern'?", errs[2].Error())
+	assert.Equal(t, file+":19:9: unknown key 'requird' in the 'commands.0.flags.0' section, did you mean 'required'?", errs[3].Error())
+	assert.Contains(t, errs[4].Error(), file+":24:3: invalid 'logs.level'")
+}
+
+func TestFindCliConfigFilesWithConfigFlag(t *testing.T) {
+	file := "../../examples/tests/cli-config/invalid/atmos.yaml"
+
+	configFiles, err := cfg.FindCliConfigFiles(schema.ConfigAndStacksInfo{CliConfigFile: file})
+	assert.Nil(t, err)
+	assert.Equal(t, file, configFiles[len(configFiles)-1])
+
+	err = e.ExecuteValidateConfig(configFiles)
+	u.LogError(err)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), file+":8:5: unknown key 'auto_generate_backend_files'")
+
+	_, err = cfg.FindCliConfigFiles(schema.ConfigAndStacksInfo{CliConfigFile: "does-not-exist/atmos.yaml"})
+	assert.NotNil(t, err)
+	assert.Equal(t, "the CLI config file 'does-not-exist/atmos.yaml' specified in the '--config' flag does not exist", err.Error())
+}
@@ -0,0 +1,58 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	e "github.com/cloudposse/atmos/internal/exec"
+	cfg "github.com/cloudposse/atmos/pkg/config"
+	"github.com/cloudposse/atmos/pkg/schema"
+	u "github.com/cloudposse/atmos/pkg/utils"
+)
+
+func TestValidatePolicies(t *testing.T) {
+	cliConfig, err := cfg.InitCliConfig(schema.ConfigAndStacksInfo{}, true)
+	assert.Nil(t, err)
+
+	// The policy with the 'repo' scope validates all stacks at once
+	err = e.ExecuteValidatePolicies(cliConfig, []string{"consistent-backend-type"})
+	assert.Nil(t, err)
+
+	// The policy with the 'stack' scope validates each stack separately
+	err = e.ExecuteValidatePolicies(cliConfig, []string{"eks-requires-vpc"})
+	u.LogError(err)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "policy 'eks-requires-vpc' failed in the stack 'tenant1-uw1-test-1'")
+	assert.Contains(t, err.Error(), "policy 'eks-requires-vpc' failed in the stack 'tenant1-uw2-test-1'")
+	assert.NotContains(t, err.Error(), "tenant1-ue2-dev")
+
+	err = e.ExecuteValidatePolicies(cliConfig, []string{"undefined-policy"})
+	assert.NotNil(t, err)
+}
+
+func TestValidateTest(t *testing.T) {
+	cliConfig, err := cfg.InitCliConfig(schema.ConfigAndStacksInfo{}, true)
+	assert.Nil(t, err)
+
+	results, err := e.ExecuteValidateTest(cliConfig, "", nil)
+	assert.Nil(t, err)
+
+	var opaTests, fixtureTests int
+	for _, r := range results {
+		assert.True(t, r.Passed, "%s: %s %v", r.File, r.Name, r.Messages)
+		if r.Type == "opa" {
+			opaTests++
+		} else {
+			fixtureTests++
+		}
+	}
+	assert.Equal(t, 3, opaTests)
+	assert.Equal(t, 3, fixtureTests)
+
+	// Run only the tests with the names matching the regular expression
+	results, err = e.ExecuteValidateTest(cliConfig, "structured", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(results))
+	assert.Equal(t, "infra/vpc structured policy in tenant1-ue2-dev", results[0].Name)
+}
@@ -0,0 +1,27 @@
+package validate
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	e "github.com/cloudposse/atmos/internal/exec"
+)
+
+func TestValidateSchemaExport(t *testing.T) {
+	// The bundled Atmos manifest JSON Schema is the same as the schema published on the website
+	publishedSchema, err := os.ReadFile("../../website/static/schemas/atmos/atmos-manifest/1.0/atmos-manifest.json")
+	assert.Nil(t, err)
+
+	jsonSchema, err := e.ExecuteValidateSchemaExport("manifest")
+	assert.Nil(t, err)
+	assert.Equal(t, string(publishedSchema), jsonSchema)
+
+	jsonSchema, err = e.ExecuteValidateSchemaExport("config")
+	assert.Nil(t, err)
+	assert.Contains(t, jsonSchema, `"$schema": "https://json-schema.org/draft/2020-12/schema"`)
+
+	_, err = e.ExecuteValidateSchemaExport("invalid")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,137 @@
+package validate
+
+import (
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	cp "github.com/otiai10/copy"
+	"github.com/stretchr/testify/assert"
+
+	e "github.com/cloudposse/atmos/internal/exec"
+	cfg "github.com/cloudposse/atmos/pkg/config"
+	"github.com/cloudposse/atmos/pkg/schema"
+)
+
+func TestValidateSpacelift(t *testing.T) {
+	cliConfig, err := cfg.InitCliConfig(schema.ConfigAndStacksInfo{}, true)
+	assert.Nil(t, err)
+
+	errorMessages, err := e.ExecuteValidateSpacelift(cliConfig, "stacks/%s.yaml", 255)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(errorMessages))
+}
+
+func TestValidateSpaceliftMaxLabelLength(t *testing.T) {
+	cliConfig, err := cfg.InitCliConfig(schema.ConfigAndStacksInfo{}, true)
+	assert.Nil(t, err)
+
+	errorMessages, err := e.ExecuteValidateSpacelift(cliConfig, "stacks/%s.yaml", 20)
+	assert.Nil(t, err)
+	assert.NotEqual(t, 0, len(errorMessages))
+
+	found := false
+	for _, m := range errorMessages {
+		if strings.Contains(m, "the label 'folder:component/top-level-component1'") {
+			found = true
+			assert.Contains(t, m, "exceeds the maximum label length of 20 characters")
+		}
+	}
+	assert.True(t, found)
+}
+
+func TestValidateSpaceliftErrors(t *testing.T) {
+	// Add the stack with the invalid Spacelift stacks to a copy of the fixtures
+	basePath := t.TempDir()
+	err := cp.Copy("../../examples/tests", basePath)
+	assert.Nil(t, err)
+	t.Setenv("ATMOS_BASE_PATH", basePath)
+
+	manifest := `
+vars:
+  namespace: cp
+  tenant: tenant9
+  environment: ue2
+  stage: test
+
+components:
+  terraform:
+    vpc-1:
+      metadata:
+        component: infra/vpc
+      settings:
+        spacelift:
+          workspace_enabled: true
+          stack_name_pattern: "{tenant}-{environment}-{stage}-vpc"
+    vpc-2:
+      metadata:
+        component: infra/vpc
+      settings:
+        spacelift:
+          workspace_enabled: true
+          stack_name_pattern: "{tenant}-{environment}-{stage}-vpc"
+    vpc-3:
+      metadata:
+        component: infra/vpc
+      settings:
+        spacelift:
+          workspace_enabled: true
+          stack_name_pattern: "{tenant}-{environment}-{stage}-VPC"
+    dns.zone:
+      metadata:
+        component: infra/vpc
+      settings:
+        spacelift:
+          workspace_enabled: true
+    vpc-disabled:
+      metadata:
+        component: infra/vpc
+      settings:
+        spacelift:
+          workspace_enabled: false
+    app:
+      metadata:
+        component: infra/vpc
+      settings:
+        spacelift:
+          workspace_enabled: true
+        depends_on:
+          1:
+            component: missing-component
+          2:
+            component: vpc-disabled
+`
+	err = os.WriteFile(path.Join(basePath, "stacks/orgs/cp/tenant9-ue2-test.yaml"), []byte(manifest), 0644)
+	assert.Nil(t, err)
+
+	cliConfig, err := cfg.InitCliConfig(schema.ConfigAndStacksInfo{}, true)
+	assert.Nil(t, err)
+
+	errorMessages, err := e.ExecuteValidateSpacelift(cliConfig, "stacks/%s.yaml", 255)
+	assert.Nil(t, err)
+
+	errors := strings.Join(errorMessages, "\n")
+
+	// The dangling `depends_on` dependency on the component that is not defined in the stack
+	assert.Contains(t, errors, "the component 'app' in the stack 'tenant9-ue2-test' specifies 'settings.depends_on' dependency "+
+		"on the component 'missing-component' in the stack 'tenant9-ue2-test'")
+
+	// The dependency on the component without a Spacelift stack is skipped (the same as in `atmos spacelift generate stacks`)
+	assert.NotContains(t, errors, "'vpc-disabled'")
+
+	// The duplicate Spacelift stack names
+	assert.Contains(t, errors, "Duplicate Spacelift stack name 'tenant9-ue2-test-vpc' for component 'vpc-2'")
+
+	// The Spacelift stack names that produce the same Spacelift stack ID
+	assert.Contains(t, errors, "duplicate Spacelift stack ID 'tenant9-ue2-test-vpc' for the Spacelift stacks:\n"+
+		"'tenant9-ue2-test-VPC' for the component 'vpc-3' in the stack 'tenant9-ue2-test'\n"+
+		"'tenant9-ue2-test-vpc' for the component 'vpc-1' in the stack 'tenant9-ue2-test'")
+
+	// The Spacelift stack name with invalid characters
+	assert.Contains(t, errors, "the Spacelift stack name 'tenant9-ue2-test-dns.zone' for the component 'dns.zone' in the stack 'tenant9-ue2-test' "+
+		"contains invalid characters")
+
+	// The existing stacks are valid
+	assert.Equal(t, 4, len(errorMessages))
+}
@@ -1,12 +1,16 @@
 package validate
 
 import (
+	"path"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 
 	"github.com/cloudposse/atmos/cmd"
 	e "github.com/cloudposse/atmos/internal/exec"
+	cfg "github.com/cloudposse/atmos/pkg/config"
+	"github.com/cloudposse/atmos/pkg/schema"
 	u "github.com/cloudposse/atmos/pkg/utils"
 )
 
@@ -21,3 +25,59 @@ func TestValidateStacksCommandWithAtmosManifestJsonSchema(t *testing.T) {
 	u.LogError(err)
 	assert.NotNil(t, err)
 }
+
+func TestValidateComponentVarsWithGeneratedSchemas(t *testing.T) {
+	cliConfig, err := cfg.InitCliConfig(schema.ConfigAndStacksInfo{}, true)
+	assert.Nil(t, err)
+
+	errorMessages, err := e.ValidateComponentVarsWithGeneratedSchemas(cliConfig)
+	assert.Nil(t, err)
+
+	found := false
+	for _, m := range errorMessages {
+		if strings.Contains(m, "the component 'infra/vpc' in the stack 'tenant1-ue2-dev'") {
+			found = true
+			assert.Contains(t, m, "vars: missing properties: 'subnet_type_tag_key'")
+			assert.Contains(t, m, "vars: additionalProperties 'dns_hostnames_enabled' not allowed")
+		}
+	}
+	assert.True(t, found)
+}
+
+func TestCheckComponentVars(t *testing.T) {
+	cliConfig, err := cfg.InitCliConfig(schema.ConfigAndStacksInfo{}, true)
+	assert.Nil(t, err)
+
+	errorMessages, err := e.CheckComponentVars(cliConfig)
+	assert.Nil(t, err)
+
+	assert.Contains(t, errorMessages, "the component 'infra/vpc' in the stack 'tenant1-ue2-dev' (Terraform component 'infra/vpc'):\n"+
+		"  undeclared variable 'dns_hostnames_enabled'\n"+
+		"  required variable 'subnet_type_tag_key' has no value")
+
+	assert.Contains(t, errorMessages, "the component 'top-level-component2' in the stack 'tenant1-ue2-test-1' (Terraform component 'top-level-component1'):\n"+
+		"  required variable 'service_1_name' has no value\n"+
+		"  required variable 'service_2_name' has no value")
+}
+
+func TestValidateStackManifestsStrict(t *testing.T) {
+	cliConfig, err := cfg.InitCliConfig(schema.ConfigAndStacksInfo{}, true)
+	assert.Nil(t, err)
+
+	// The stack manifest is not validated with the Atmos manifest JSON Schema, which also rejects the unknown sections
+	cliConfig.Validate.Stacks.Strict = true
+	stackConfigFilesAbsolutePaths := []string{path.Join(cliConfig.StacksBaseAbsolutePath, "catalog/strict/unknown-sections.yaml")}
+
+	// If `workflows.base_path` is not set, the unknown sections are still reported in all the stack manifests
+	cliConfig.Workflows.BasePath = ""
+	errorMessages, err := e.ValidateStackManifests(cliConfig, stackConfigFilesAbsolutePaths, "")
+	assert.Nil(t, err)
+	assert.Contains(t, strings.Join(errorMessages, "\n"),
+		"catalog/strict/unknown-sections.yaml:4:1: unknown section 'setings', did you mean 'settings'?")
+
+	// The workflow manifests in the `workflows.base_path` folder are not checked for unknown sections
+	cliConfig.Workflows.BasePath = "stacks/catalog/strict"
+	errorMessages, err = e.ValidateStackManifests(cliConfig, stackConfigFilesAbsolutePaths, "")
+	assert.Nil(t, err)
+	assert.NotContains(t, strings.Join(errorMessages, "\n"), "unknown section")
+}